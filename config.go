@@ -5,118 +5,134 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"github.com/Shopify/sarama"
 	"github.com/hashicorp/go-retryablehttp"
 	"log"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
 )
 
 type Config struct {
-	ApiEndpoint string
-	Email       string
-	Password    string
-	Me          *Me
-	Session     *Session
-	AccessToken *AccessToken
-	Mutex       sync.Mutex
+	ApiEndpoint             string
+	Email                   string
+	Password                string
+	SchemaRegistryEndpoint  string
+	SchemaRegistryApiKey    string
+	SchemaRegistryApiSecret string
+	CloudApiEndpoint        string
+	CloudApiKey             string
+	CloudApiSecret          string
+	Me                      *Me
+	Session                 *Session
+	AccessToken             *AccessToken
+	Mutex                   sync.Mutex
 }
 
 type Session struct {
-	Error interface{} "json:error"
-	Token string      "json:token"
-	User  User        "json:user"
+	Error interface{} `json:"error"`
+	Token string      `json:"token"`
+	User  User        `json:"user"`
 }
 
 type User struct {
 	Id                 int         `json:"id"`
-	Email              string      "json:email"
+	Email              string      `json:"email"`
 	FirstName          string      `json:"first_name"`
 	LastName           string      `json:"last_name"`
 	OrganizationId     int         `json:"organization_id"`
-	Deactivated        bool        "json:deactivated"
-	Verified           string      "json:verified"
-	Created            string      "json:created"
-	Modified           string      "json:modified"
+	Deactivated        bool        `json:"deactivated"`
+	Verified           string      `json:"verified"`
+	Created            string      `json:"created"`
+	Modified           string      `json:"modified"`
 	PasswordChanged    string      `json:"password_changed"`
 	ServiceName        string      `json:"service_name"`
 	ServiceDescription string      `json:"service_description"`
 	ServiceAccount     bool        `json:"service_account"`
-	Sso                interface{} "json:sso"
-	Preferences        interface{} "json:preferences"
-	Internal           bool        "json:internal"
+	Sso                interface{} `json:"sso"`
+	Preferences        interface{} `json:"preferences"`
+	Internal           bool        `json:"internal"`
 }
 
 type Me struct {
-	User         User         "json:user"
-	Account      Account      "json:account"
-	Organization Organization "json:organization"
-	Error        interface{}  "json:error"
-	Accounts     []Account    "json:accounts"
+	User         User         `json:"user"`
+	Account      Account      `json:"account"`
+	Organization Organization `json:"organization"`
+	Error        interface{}  `json:"error"`
+	Accounts     []Account    `json:"accounts"`
 }
 
 type Account struct {
-	Id             string      "json:id"
-	Name           string      "json:name"
+	Id             string      `json:"id"`
+	Name           string      `json:"name"`
 	OrganizationId int         `json:"organization_id"`
-	Deactivated    bool        "json:deactivated"
-	Created        string      "json:created"
-	Modified       string      "json:modified"
-	Config         interface{} "json:config"
-	Internal       bool        "json:internal"
+	Deactivated    bool        `json:"deactivated"`
+	Created        string      `json:"created"`
+	Modified       string      `json:"modified"`
+	Config         interface{} `json:"config"`
+	Internal       bool        `json:"internal"`
 }
 
 type Organization struct {
-	Id               int         "json:id"
-	Name             string      "json:name"
-	Deactivated      bool        "json:deactivated"
+	Id               int         `json:"id"`
+	Name             string      `json:"name"`
+	Deactivated      bool        `json:"deactivated"`
 	StripeCustomerId string      `json:"stripe_customer_id"`
-	Created          string      "json:created"
-	Modified         string      "json:modified"
+	Created          string      `json:"created"`
+	Modified         string      `json:"modified"`
 	BillingEmail     string      `json:"billing_email"`
-	Plan             interface{} "json:plan"
-	Saml             interface{} "json:saml"
-	Sso              interface{} "json:sso"
-	Marketplace      interface{} "json:marketplace"
+	Plan             interface{} `json:"plan"`
+	Saml             interface{} `json:"saml"`
+	Sso              interface{} `json:"sso"`
+	Marketplace      interface{} `json:"marketplace"`
 	ResourceId       string      `json:"resource_id"`
 	HasEntitlement   bool        `json:"has_entitlement"`
 	ShowBilling      bool        `json:"show_billing"`
-	AuditLog         interface{} `json:"audit_log"`
+	AuditLog         AuditLog    `json:"audit_log"`
+}
+
+type AuditLog struct {
+	ClusterId        string `json:"cluster_id"`
+	AccountId        string `json:"account_id"`
+	TopicName        string `json:"topic_name"`
+	ServiceAccountId int    `json:"service_account_id"`
 }
 
 type AccessToken struct {
-	Error interface{} "json:error"
-	Token string      "json:token"
+	Error interface{} `json:"error"`
+	Token string      `json:"token"`
 }
 
 type Cluster struct {
-	Id                string "json:id"
-	Name              string "json:name"
+	Id                string `json:"id"`
+	Name              string `json:"name"`
 	AccountId         string `json:"account_id"`
 	NetworkIngress    int    `json:"network_ingress"`
-	NetworkEgress     int    `"json:"network_egress"`
-	Storage           int    "json:storage"
-	Durability        string "json:durability"
-	Status            string "json:status"
-	Endpoint          string "json:endpoint"
-	Region            string "json:region"
-	Created           string "json:created"
-	Modified          string "json:modified"
+	NetworkEgress     int    `json:"network_egress"`
+	Storage           int    `json:"storage"`
+	Durability        string `json:"durability"`
+	Status            string `json:"status"`
+	Endpoint          string `json:"endpoint"`
+	Region            string `json:"region"`
+	Created           string `json:"created"`
+	Modified          string `json:"modified"`
 	ServiceProvider   string `json:"service_provider"`
 	OrganizationId    int    `json:"organization_id"`
-	Enterprise        bool   "json:enterprise"
+	Enterprise        bool   `json:"enterprise"`
 	K8SClusterId      string `json:"k8s_cluster_id"`
 	PhysicalClusterId string `json:"physical_cluster_id"`
-	PricePerHourd     string `json:"price_per_hour"`
+	PricePerHour      string `json:"price_per_hour"`
 	AccruedThisCycle  string `json:"accrued_this_cycle"`
 	LegacyEndpoint    bool   `json:"legacy_endpoint"`
-	Type              string "json:type"
+	Type              string `json:"type"`
 	ApiEndpoint       string `json:"api_endpoint"`
 	InternalProxy     bool   `json:"internal_proxy"`
 	IsSlaEnabled      bool   `json:"is_sla_enabled"`
 	IsSchedulable     bool   `json:"is_schedulable"`
-	Dedicated         bool   "json:dedicated"
+	Dedicated         bool   `json:"dedicated"`
 }
 
 func (cluster *Cluster) Host() string {
@@ -147,21 +163,34 @@ func (cluster *Cluster) Port() int {
 }
 
 type ApiKey struct {
-	Id              int           `json:"Id"`
-	Key             string        `json:"key"`
-	Secret          string        `json:"secret"`
-	HashSecret      string        `json:"hashed_secret"`
-	HashFunction    string        `json:"hash_function"`
-	SaslMechanism   string        `json:"sasl_mechanism"`
-	UserId          int           `json:"user_id"`
-	Deactivated     bool          `json:"deactivated"`
-	Created         string        `json:"created"`
-	Modified        string        `json:"modified"`
-	Description     string        `json:"description"`
-	Internal        interface{}   `json:"internal"`
-	LogicalClusters []interface{} `json:"logical_clusters"`
-	AccountId       string        `json:"account_id"`
-	ServiceAccount  bool          `json:"service_account"`
+	Id              int              `json:"Id"`
+	Key             string           `json:"key"`
+	Secret          string           `json:"secret"`
+	HashSecret      string           `json:"hashed_secret"`
+	HashFunction    string           `json:"hash_function"`
+	SaslMechanism   string           `json:"sasl_mechanism"`
+	UserId          int              `json:"user_id"`
+	Deactivated     bool             `json:"deactivated"`
+	Created         string           `json:"created"`
+	Modified        string           `json:"modified"`
+	Description     string           `json:"description"`
+	Internal        interface{}      `json:"internal"`
+	LogicalClusters []LogicalCluster `json:"logical_clusters"`
+	AccountId       string           `json:"account_id"`
+	ServiceAccount  bool             `json:"service_account"`
+}
+
+type LogicalCluster struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+func (apiKey *ApiKey) ResourceIds() []string {
+	resourceIds := []string{}
+	for _, logicalCluster := range apiKey.LogicalClusters {
+		resourceIds = append(resourceIds, logicalCluster.Id)
+	}
+	return resourceIds
 }
 
 type ApiKeysResponse struct {
@@ -182,29 +211,29 @@ type CreateClusterResponse struct {
 }
 
 type Clusters struct {
-	Error    interface{} "json:error"
-	Clusters []Cluster   "json:clusters"
+	Error    interface{} `json:"error"`
+	Clusters []Cluster   `json:"clusters"`
 }
 
 type KafkaHost struct {
-	Id   int    "json:id"
-	Host string "json:host"
-	Port int    "json:port"
-	Rack int    "json:rack"
+	Id   int    `json:"id"`
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	Rack int    `json:"rack"`
 }
 
 type KafkaPartition struct {
-	Partition int         "json:partition"
-	Leader    KafkaHost   "json:leader"
-	Replicas  []KafkaHost "json:replicas"
-	Isr       []KafkaHost "json:isr" // ??
+	Partition int         `json:"partition"`
+	Leader    KafkaHost   `json:"leader"`
+	Replicas  []KafkaHost `json:"replicas"`
+	Isr       []KafkaHost `json:"isr"` // ??
 }
 
 type KafkaTopic struct {
-	Name                 string           "json:name"
-	Internal             bool             "json:internal"
-	AuthorizedOperations []string         "json:authorizedOperations"
-	Partitions           []KafkaPartition "json:partitions"
+	Name                 string           `json:"name"`
+	Internal             bool             `json:"internal"`
+	AuthorizedOperations []string         `json:"authorizedOperations"`
+	Partitions           []KafkaPartition `json:"partitions"`
 	Configs              []KafkaTopicConfig
 }
 
@@ -224,10 +253,10 @@ type CreateTopicConfig struct {
 }
 
 type CreateTopicRequest struct {
-	Name              string            `json:"name""`
+	Name              string            `json:"name"`
 	NumPartitions     int               `json:"numPartitions"`
 	ReplicationFactor int               `json:"replicationFactor"`
-	Configs           CreateTopicConfig `json:"configs""`
+	Configs           CreateTopicConfig `json:"configs"`
 }
 
 type ApiKeyRequest struct {
@@ -241,10 +270,25 @@ type ApiKeyRequestDelete struct {
 	LogicalClusters []LogicalClusterRequest `json:"logical_clusters"`
 }
 
+type ApiKeyRequestUpdate struct {
+	Id              int                     `json:"id"`
+	AccountId       string                  `json:"account_id"`
+	Deactivated     bool                    `json:"deactivated"`
+	LogicalClusters []LogicalClusterRequest `json:"logical_clusters"`
+}
+
 type LogicalClusterRequest struct {
 	Id string `json:"id"`
 }
 
+func logicalClusterRequests(resourceIds []string) []LogicalClusterRequest {
+	logicalClusters := []LogicalClusterRequest{}
+	for _, resourceId := range resourceIds {
+		logicalClusters = append(logicalClusters, LogicalClusterRequest{Id: resourceId})
+	}
+	return logicalClusters
+}
+
 type CreateApiKeyRequest struct {
 	ApiKey ApiKeyRequest `json:"api_key"`
 }
@@ -253,6 +297,10 @@ type DeleteApiKeyRequest struct {
 	ApiKey ApiKeyRequestDelete `json:"api_key"`
 }
 
+type UpdateApiKeyRequest struct {
+	ApiKey ApiKeyRequestUpdate `json:"api_key"`
+}
+
 type CreateApiKeyResponse struct {
 	ApiKey ApiKey      `json:"api_key"`
 	Error  interface{} `json:"error"`
@@ -297,6 +345,18 @@ func (c *Config) connect() error {
 	return nil
 }
 
+// refreshMe reloads the user and its accounts, connect only loads them once per run
+func (c *Config) refreshMe() error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+	me, err := getMe(c.ApiEndpoint, *c.Session)
+	if err != nil {
+		return err
+	}
+	c.Me = me
+	return nil
+}
+
 func (c *Config) getClusters() (*Clusters, error) {
 	return c.getClustersPerAccount(c.Me.Account.Id)
 }
@@ -379,7 +439,7 @@ func (c *Config) deleteCluster(cluster Cluster) error {
 	if err != nil {
 		return err
 	}
-	log.Printf(bytes.NewBuffer(bytesRepresentation).String())
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
 	client := retryablehttp.NewClient()
 	requestDeleteCluster, err := retryablehttp.NewRequest("DELETE", "https://confluent.cloud/api/clusters/"+cluster.Id, bytes.NewBuffer(bytesRepresentation))
 	if err != nil {
@@ -419,7 +479,7 @@ func (c *Config) updateCluster(cluster Cluster, newName string) (*Cluster, error
 	if err != nil {
 		return nil, err
 	}
-	log.Printf(bytes.NewBuffer(bytesRepresentation).String())
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
 	client := retryablehttp.NewClient()
 	requestUpdateCluster, err := retryablehttp.NewRequest("PUT", "https://confluent.cloud/api/clusters/"+cluster.Id, bytes.NewBuffer(bytesRepresentation))
 	if err != nil {
@@ -442,24 +502,29 @@ func (c *Config) updateCluster(cluster Cluster, newName string) (*Cluster, error
 	return &UpdateClusterResponse.Cluster, nil
 }
 
-func (c *Config) getApiKey(cluster Cluster, keyId int) (*ApiKey, error) {
-	if apiKeys, err := c.getApiKeys(cluster); err != nil {
+func (c *Config) getApiKey(accountId string, resourceId string, keyId int) (*ApiKey, error) {
+	if apiKeys, err := c.getApiKeys(accountId, resourceId); err != nil {
 		return nil, err
 	} else {
 		for _, apiKey := range apiKeys {
-			log.Printf("Loading key " + strconv.Itoa(apiKey.Id))
+			log.Print("Loading key " + strconv.Itoa(apiKey.Id))
 			if apiKey.Id == keyId {
 				return &apiKey, nil
 			}
 		}
-		log.Printf("Unable to find Api Key with ID " + strconv.Itoa(keyId))
+		log.Print("Unable to find Api Key with ID " + strconv.Itoa(keyId))
 		return nil, nil // Not Found
 	}
 }
 
-func (c *Config) getApiKeys(cluster Cluster) ([]ApiKey, error) {
+// getApiKeys lists the API keys of an account, restricted to a cluster when resourceId is set
+func (c *Config) getApiKeys(accountId string, resourceId string) ([]ApiKey, error) {
+	url := c.ApiEndpoint + "/api/api_keys?account_id=" + accountId
+	if resourceId != "" {
+		url += "&cluster_id=" + resourceId
+	}
 	client := retryablehttp.NewClient()
-	requestListApiKeys, err := retryablehttp.NewRequest("GET", "https://confluent.cloud/api/api_keys?account_id="+cluster.AccountId+"&cluster_id="+cluster.Id, nil)
+	requestListApiKeys, err := retryablehttp.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -475,17 +540,15 @@ func (c *Config) getApiKeys(cluster Cluster) ([]ApiKey, error) {
 
 	var GetApiKeysResponse GetApiKeysResponse
 	json.NewDecoder(respApiKeys.Body).Decode(&GetApiKeysResponse)
-	log.Printf("API Keys: " + strconv.Itoa(len(GetApiKeysResponse.ApiKeys)))
+	log.Print("API Keys: " + strconv.Itoa(len(GetApiKeysResponse.ApiKeys)))
 	return GetApiKeysResponse.ApiKeys, nil
 }
 
-func (c *Config) createApiKey(cluster Cluster) (*ApiKey, error) {
+func (c *Config) createApiKey(accountId string, resourceIds []string) (*ApiKey, error) {
 	CreateApiKeyRequest := CreateApiKeyRequest{
 		ApiKey: ApiKeyRequest{
-			AccountId: cluster.AccountId,
-			LogicalClusters: []LogicalClusterRequest{
-				{Id: cluster.Id},
-			},
+			AccountId:       accountId,
+			LogicalClusters: logicalClusterRequests(resourceIds),
 		},
 	}
 
@@ -493,9 +556,9 @@ func (c *Config) createApiKey(cluster Cluster) (*ApiKey, error) {
 	if err != nil {
 		return nil, err
 	}
-	log.Printf(bytes.NewBuffer(bytesRepresentation).String())
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
 	client := retryablehttp.NewClient()
-	requestCreateApiKey, err := retryablehttp.NewRequest("POST", "https://confluent.cloud/api/api_keys", bytes.NewBuffer(bytesRepresentation))
+	requestCreateApiKey, err := retryablehttp.NewRequest("POST", c.ApiEndpoint+"/api/api_keys", bytes.NewBuffer(bytesRepresentation))
 	if err != nil {
 		return nil, err
 	}
@@ -516,14 +579,12 @@ func (c *Config) createApiKey(cluster Cluster) (*ApiKey, error) {
 	return &CreateApiKeyResponse.ApiKey, nil
 }
 
-func (c *Config) deleteApiKey(cluster Cluster, keyId int) error {
+func (c *Config) deleteApiKey(accountId string, resourceIds []string, keyId int) error {
 	DeleteApiKeyRequest := DeleteApiKeyRequest{
 		ApiKey: ApiKeyRequestDelete{
-			Id:        keyId,
-			AccountId: cluster.AccountId,
-			LogicalClusters: []LogicalClusterRequest{
-				{Id: cluster.Id},
-			},
+			Id:              keyId,
+			AccountId:       accountId,
+			LogicalClusters: logicalClusterRequests(resourceIds),
 		},
 	}
 
@@ -531,9 +592,9 @@ func (c *Config) deleteApiKey(cluster Cluster, keyId int) error {
 	if err != nil {
 		return err
 	}
-	log.Printf(bytes.NewBuffer(bytesRepresentation).String())
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
 	client := retryablehttp.NewClient()
-	requestDeleteApiKey, err := retryablehttp.NewRequest("DELETE", "https://confluent.cloud/api/api_keys/"+strconv.Itoa(keyId), bytes.NewBuffer(bytesRepresentation))
+	requestDeleteApiKey, err := retryablehttp.NewRequest("DELETE", c.ApiEndpoint+"/api/api_keys/"+strconv.Itoa(keyId), bytes.NewBuffer(bytesRepresentation))
 	if err != nil {
 		return err
 	}
@@ -552,7 +613,54 @@ func (c *Config) deleteApiKey(cluster Cluster, keyId int) error {
 	return nil
 }
 
+func (c *Config) setApiKeyDeactivated(accountId string, resourceIds []string, keyId int, deactivated bool) error {
+	UpdateApiKeyRequest := UpdateApiKeyRequest{
+		ApiKey: ApiKeyRequestUpdate{
+			Id:              keyId,
+			AccountId:       accountId,
+			Deactivated:     deactivated,
+			LogicalClusters: logicalClusterRequests(resourceIds),
+		},
+	}
+
+	bytesRepresentation, err := json.Marshal(UpdateApiKeyRequest)
+	if err != nil {
+		return err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestUpdateApiKey, err := retryablehttp.NewRequest("PUT", c.ApiEndpoint+"/api/api_keys/"+strconv.Itoa(keyId), bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return err
+	}
+	requestUpdateApiKey.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestUpdateApiKey.Header.Set("Content-Type", "application/json")
+	responseUpdateApiKey, err := client.Do(requestUpdateApiKey)
+	if err != nil {
+		return err
+	}
+	defer responseUpdateApiKey.Body.Close()
+
+	if responseUpdateApiKey.StatusCode != 200 {
+		return errors.New("HTTP error code updating API Key : " + strconv.Itoa(responseUpdateApiKey.StatusCode))
+	}
+
+	return nil
+}
+
 func (c *Config) getClusterPerAccount(accountId string, clusterId string) (*Cluster, error) {
+	cluster, err := c.findClusterPerAccount(accountId, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, errors.New("Unable to find Cluster with Id " + clusterId + " for account " + accountId)
+	}
+	return cluster, nil
+}
+
+// findClusterPerAccount returns nil when the account has no such cluster, so that Read can tell a deleted cluster from an API error
+func (c *Config) findClusterPerAccount(accountId string, clusterId string) (*Cluster, error) {
 	clusters, err := c.getClustersPerAccount(accountId)
 	if err != nil {
 		return nil, err
@@ -562,8 +670,7 @@ func (c *Config) getClusterPerAccount(accountId string, clusterId string) (*Clus
 			return &cluster, nil
 		}
 	}
-
-	return nil, errors.New("Unable to find Cluster with Id " + clusterId + " for account " + accountId)
+	return nil, nil
 }
 
 func (c *Config) getClusterPerAccountAndName(accountId string, clusterName string) (*Cluster, error) {
@@ -716,7 +823,7 @@ func (c *Config) updateTopicConfig(cluster Cluster, topicName string, params []K
 	var configs []map[string]interface{}
 
 	for _, param := range params {
-		log.Printf(param.Name + "=" + param.Value)
+		log.Print(param.Name + "=" + param.Value)
 		configs = append(configs, map[string]interface{}{
 			"name":  param.Name,
 			"value": param.Value,
@@ -740,6 +847,17 @@ func (c *Config) updateTopicConfig(cluster Cluster, topicName string, params []K
 	return nil
 }
 
+// resetTopicConfigs puts the configs back to their default value
+func (c *Config) resetTopicConfigs(cluster Cluster, topicName string, names []string) error {
+	var data []map[string]string
+	for _, name := range names {
+		data = append(data, map[string]string{"name": name, "operation": "DELETE"})
+	}
+	body := map[string]interface{}{"data": data}
+	_, err := c.kafkaRestRequest(cluster, "POST", "/topics/"+url.PathEscape(topicName)+"/configs:alter", body, nil)
+	return err
+}
+
 func (c *Config) deleteTopic(cluster Cluster, topicName string) error {
 	client := http.Client{}
 	requestDeleteTopic, err := http.NewRequest("DELETE", cluster.ApiEndpoint+"/2.0/kafka/"+cluster.Id+"/topics/"+topicName, nil)
@@ -819,3 +937,1507 @@ func getAccessToken(apiEndpoint string, session Session) (*AccessToken, error) {
 	json.NewDecoder(respAccessToken.Body).Decode(&accessToken)
 	return &accessToken, nil
 }
+
+// checkApiKey returns false while the key is not usable yet. Besides 401 and 403, a new key can
+// get a 404 or a 5xx from the REST endpoint while it propagates.
+func (c *Config) checkApiKey(cluster Cluster, key string, secret string) (bool, error) {
+	client := http.Client{}
+	requestCheckApiKey, err := http.NewRequest("GET", cluster.ApiEndpoint+"/kafka/v3/clusters/"+cluster.Id+"/topics", nil)
+	if err != nil {
+		return false, err
+	}
+	requestCheckApiKey.SetBasicAuth(key, secret)
+	respCheckApiKey, err := client.Do(requestCheckApiKey)
+	if err != nil {
+		return false, err
+	}
+	defer respCheckApiKey.Body.Close()
+	switch {
+	case respCheckApiKey.StatusCode == 401, respCheckApiKey.StatusCode == 403, respCheckApiKey.StatusCode == 404, respCheckApiKey.StatusCode >= 500:
+		return false, nil
+	}
+	if respCheckApiKey.StatusCode != 200 {
+		return false, errors.New("HTTP error code checking API Key: " + strconv.Itoa(respCheckApiKey.StatusCode))
+	}
+	return true, nil
+}
+
+type AccountResponse struct {
+	Error   interface{} `json:"error"`
+	Account Account     `json:"account"`
+}
+
+func (c *Config) getAccount(accountId string) (*Account, error) {
+	client := retryablehttp.NewClient()
+	requestAccount, err := retryablehttp.NewRequest("GET", c.ApiEndpoint+"/api/accounts/"+accountId, nil)
+	if err != nil {
+		return nil, err
+	}
+	requestAccount.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	respAccount, err := client.Do(requestAccount)
+	if err != nil {
+		return nil, err
+	}
+	defer respAccount.Body.Close()
+	if respAccount.StatusCode == 404 {
+		return nil, nil // Not Found
+	}
+	if respAccount.StatusCode != 200 {
+		return nil, errors.New("HTTP error code getting account: " + strconv.Itoa(respAccount.StatusCode))
+	}
+
+	var AccountResponse AccountResponse
+	json.NewDecoder(respAccount.Body).Decode(&AccountResponse)
+	return &AccountResponse.Account, nil
+}
+
+func (c *Config) createAccount(name string) (*Account, error) {
+	createAccountRequest := map[string]map[string]interface{}{
+		"account": {
+			"name":            name,
+			"organization_id": c.Me.Organization.Id,
+		},
+	}
+	bytesRepresentation, err := json.Marshal(createAccountRequest)
+	if err != nil {
+		return nil, err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestCreateAccount, err := retryablehttp.NewRequest("POST", c.ApiEndpoint+"/api/accounts", bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return nil, err
+	}
+	requestCreateAccount.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestCreateAccount.Header.Set("Content-Type", "application/json")
+	responseCreateAccount, err := client.Do(requestCreateAccount)
+	if err != nil {
+		return nil, err
+	}
+	defer responseCreateAccount.Body.Close()
+
+	if responseCreateAccount.StatusCode != 200 && responseCreateAccount.StatusCode != 201 {
+		return nil, errors.New("HTTP error code creating account : " + strconv.Itoa(responseCreateAccount.StatusCode))
+	}
+
+	var CreateAccountResponse AccountResponse
+	json.NewDecoder(responseCreateAccount.Body).Decode(&CreateAccountResponse)
+	return &CreateAccountResponse.Account, nil
+}
+
+func (c *Config) updateAccount(account Account, newName string) (*Account, error) {
+	updateAccountRequest := map[string]map[string]interface{}{
+		"account": {
+			"id":              account.Id,
+			"name":            newName,
+			"organization_id": account.OrganizationId,
+		},
+	}
+	bytesRepresentation, err := json.Marshal(updateAccountRequest)
+	if err != nil {
+		return nil, err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestUpdateAccount, err := retryablehttp.NewRequest("PUT", c.ApiEndpoint+"/api/accounts/"+account.Id, bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return nil, err
+	}
+	requestUpdateAccount.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestUpdateAccount.Header.Set("Content-Type", "application/json")
+	responseUpdateAccount, err := client.Do(requestUpdateAccount)
+	if err != nil {
+		return nil, err
+	}
+	defer responseUpdateAccount.Body.Close()
+
+	if responseUpdateAccount.StatusCode != 200 {
+		return nil, errors.New("HTTP error code updating account : " + strconv.Itoa(responseUpdateAccount.StatusCode))
+	}
+
+	var UpdateAccountResponse AccountResponse
+	json.NewDecoder(responseUpdateAccount.Body).Decode(&UpdateAccountResponse)
+	return &UpdateAccountResponse.Account, nil
+}
+
+func (c *Config) deleteAccount(account Account) error {
+	deleteAccountRequest := map[string]map[string]interface{}{
+		"account": {
+			"id":              account.Id,
+			"organization_id": account.OrganizationId,
+		},
+	}
+	bytesRepresentation, err := json.Marshal(deleteAccountRequest)
+	if err != nil {
+		return err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestDeleteAccount, err := retryablehttp.NewRequest("DELETE", c.ApiEndpoint+"/api/accounts/"+account.Id, bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return err
+	}
+	requestDeleteAccount.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestDeleteAccount.Header.Set("Content-Type", "application/json")
+	responseDeleteAccount, err := client.Do(requestDeleteAccount)
+	if err != nil {
+		return err
+	}
+	defer responseDeleteAccount.Body.Close()
+
+	if responseDeleteAccount.StatusCode != 200 && responseDeleteAccount.StatusCode != 204 {
+		return errors.New("HTTP error code deleting account : " + strconv.Itoa(responseDeleteAccount.StatusCode))
+	}
+
+	return nil
+}
+
+type SchemaRegistry struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	AccountId       string `json:"account_id"`
+	Endpoint        string `json:"endpoint"`
+	ServiceProvider string `json:"service_provider"`
+	Location        string `json:"location"`
+	Region          string `json:"region"`
+	Status          string `json:"status"`
+	PhysicalCluster string `json:"physical_cluster"`
+	Created         string `json:"created"`
+	Modified        string `json:"modified"`
+}
+
+type SchemaRegistriesResponse struct {
+	Error    interface{}      `json:"error"`
+	Clusters []SchemaRegistry `json:"clusters"`
+}
+
+type SchemaRegistryResponse struct {
+	Error            interface{}    `json:"error"`
+	ValidationErrors interface{}    `json:"validation_errors"`
+	Cluster          SchemaRegistry `json:"cluster"`
+}
+
+// getSchemaRegistry returns the Schema Registry of an account, nil if it is not enabled
+func (c *Config) getSchemaRegistry(accountId string) (*SchemaRegistry, error) {
+	client := retryablehttp.NewClient()
+	requestSchemaRegistries, err := retryablehttp.NewRequest("GET", c.ApiEndpoint+"/api/schema_registries?account_id="+accountId, nil)
+	if err != nil {
+		return nil, err
+	}
+	requestSchemaRegistries.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	respSchemaRegistries, err := client.Do(requestSchemaRegistries)
+	if err != nil {
+		return nil, err
+	}
+	defer respSchemaRegistries.Body.Close()
+	if respSchemaRegistries.StatusCode != 200 {
+		return nil, errors.New("HTTP error code getting Schema Registry: " + strconv.Itoa(respSchemaRegistries.StatusCode))
+	}
+
+	var SchemaRegistriesResponse SchemaRegistriesResponse
+	json.NewDecoder(respSchemaRegistries.Body).Decode(&SchemaRegistriesResponse)
+	if len(SchemaRegistriesResponse.Clusters) == 0 {
+		return nil, nil // Not enabled
+	}
+	return &SchemaRegistriesResponse.Clusters[0], nil
+}
+
+func (c *Config) createSchemaRegistry(accountId string, serviceProvider string, location string) (*SchemaRegistry, error) {
+	createSchemaRegistryRequest := map[string]map[string]interface{}{
+		"config": {
+			"name":             "account schema-registry",
+			"account_id":       accountId,
+			"service_provider": serviceProvider,
+			"location":         location,
+		},
+	}
+	bytesRepresentation, err := json.Marshal(createSchemaRegistryRequest)
+	if err != nil {
+		return nil, err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestCreateSchemaRegistry, err := retryablehttp.NewRequest("POST", c.ApiEndpoint+"/api/schema_registries", bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return nil, err
+	}
+	requestCreateSchemaRegistry.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestCreateSchemaRegistry.Header.Set("Content-Type", "application/json")
+	responseCreateSchemaRegistry, err := client.Do(requestCreateSchemaRegistry)
+	if err != nil {
+		return nil, err
+	}
+	defer responseCreateSchemaRegistry.Body.Close()
+
+	if responseCreateSchemaRegistry.StatusCode != 200 {
+		return nil, errors.New("HTTP error code creating Schema Registry : " + strconv.Itoa(responseCreateSchemaRegistry.StatusCode))
+	}
+
+	var CreateSchemaRegistryResponse SchemaRegistryResponse
+	json.NewDecoder(responseCreateSchemaRegistry.Body).Decode(&CreateSchemaRegistryResponse)
+	return &CreateSchemaRegistryResponse.Cluster, nil
+}
+
+func (c *Config) deleteSchemaRegistry(schemaRegistry SchemaRegistry) error {
+	deleteSchemaRegistryRequest := map[string]map[string]interface{}{
+		"cluster": {
+			"id":         schemaRegistry.Id,
+			"account_id": schemaRegistry.AccountId,
+		},
+	}
+	bytesRepresentation, err := json.Marshal(deleteSchemaRegistryRequest)
+	if err != nil {
+		return err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestDeleteSchemaRegistry, err := retryablehttp.NewRequest("DELETE", c.ApiEndpoint+"/api/schema_registries/"+schemaRegistry.Id, bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return err
+	}
+	requestDeleteSchemaRegistry.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestDeleteSchemaRegistry.Header.Set("Content-Type", "application/json")
+	responseDeleteSchemaRegistry, err := client.Do(requestDeleteSchemaRegistry)
+	if err != nil {
+		return err
+	}
+	defer responseDeleteSchemaRegistry.Body.Close()
+
+	if responseDeleteSchemaRegistry.StatusCode != 200 && responseDeleteSchemaRegistry.StatusCode != 204 {
+		return errors.New("HTTP error code deleting Schema Registry : " + strconv.Itoa(responseDeleteSchemaRegistry.StatusCode))
+	}
+
+	return nil
+}
+
+type SchemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type SchemaRegistrySchema struct {
+	Subject    string            `json:"subject,omitempty"`
+	Version    int               `json:"version,omitempty"`
+	Id         int               `json:"id,omitempty"`
+	SchemaType string            `json:"schemaType,omitempty"`
+	Schema     string            `json:"schema"`
+	References []SchemaReference `json:"references,omitempty"`
+}
+
+// Format returns the schema type, the registry omits it for Avro schemas
+func (schema *SchemaRegistrySchema) Format() string {
+	if schema.SchemaType == "" {
+		return "AVRO"
+	}
+	return schema.SchemaType
+}
+
+type RegisterSchemaResponse struct {
+	Id int `json:"id"`
+}
+
+// SchemaRegistryClient talks to the Schema Registry REST API with an SR-scoped API key
+type SchemaRegistryClient struct {
+	Endpoint  string
+	ApiKey    string
+	ApiSecret string
+}
+
+func (c *SchemaRegistryClient) newRequest(method string, path string, body interface{}) (*retryablehttp.Request, error) {
+	var rawBody interface{}
+	if body != nil {
+		bytesRepresentation, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		log.Print(bytes.NewBuffer(bytesRepresentation).String())
+		rawBody = bytes.NewBuffer(bytesRepresentation)
+	}
+	request, err := retryablehttp.NewRequest(method, strings.TrimSuffix(c.Endpoint, "/")+path, rawBody)
+	if err != nil {
+		return nil, err
+	}
+	request.SetBasicAuth(c.ApiKey, c.ApiSecret)
+	request.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	return request, nil
+}
+
+func (c *SchemaRegistryClient) registerSchema(subject string, schema SchemaRegistrySchema) (int, error) {
+	client := retryablehttp.NewClient()
+	requestRegisterSchema, err := c.newRequest("POST", "/subjects/"+url.PathEscape(subject)+"/versions", schema)
+	if err != nil {
+		return 0, err
+	}
+	respRegisterSchema, err := client.Do(requestRegisterSchema)
+	if err != nil {
+		return 0, err
+	}
+	defer respRegisterSchema.Body.Close()
+	if respRegisterSchema.StatusCode != 200 {
+		return 0, errors.New("HTTP error code registering schema for subject " + subject + ": " + strconv.Itoa(respRegisterSchema.StatusCode))
+	}
+
+	var RegisterSchemaResponse RegisterSchemaResponse
+	json.NewDecoder(respRegisterSchema.Body).Decode(&RegisterSchemaResponse)
+	return RegisterSchemaResponse.Id, nil
+}
+
+// lookupSchema returns the version of a subject matching a schema definition, nil if it is not registered
+func (c *SchemaRegistryClient) lookupSchema(subject string, schema SchemaRegistrySchema) (*SchemaRegistrySchema, error) {
+	client := retryablehttp.NewClient()
+	requestLookupSchema, err := c.newRequest("POST", "/subjects/"+url.PathEscape(subject), schema)
+	if err != nil {
+		return nil, err
+	}
+	respLookupSchema, err := client.Do(requestLookupSchema)
+	if err != nil {
+		return nil, err
+	}
+	defer respLookupSchema.Body.Close()
+	if respLookupSchema.StatusCode == 404 {
+		return nil, nil // Not Found
+	}
+	if respLookupSchema.StatusCode != 200 {
+		return nil, errors.New("HTTP error code looking up schema for subject " + subject + ": " + strconv.Itoa(respLookupSchema.StatusCode))
+	}
+
+	var registered SchemaRegistrySchema
+	json.NewDecoder(respLookupSchema.Body).Decode(&registered)
+	return &registered, nil
+}
+
+// getSchema returns a version ("latest" or a number) of a subject, nil if it does not exist
+func (c *SchemaRegistryClient) getSchema(subject string, version string) (*SchemaRegistrySchema, error) {
+	client := retryablehttp.NewClient()
+	requestGetSchema, err := c.newRequest("GET", "/subjects/"+url.PathEscape(subject)+"/versions/"+version, nil)
+	if err != nil {
+		return nil, err
+	}
+	respGetSchema, err := client.Do(requestGetSchema)
+	if err != nil {
+		return nil, err
+	}
+	defer respGetSchema.Body.Close()
+	if respGetSchema.StatusCode == 404 {
+		return nil, nil // Not Found
+	}
+	if respGetSchema.StatusCode != 200 {
+		return nil, errors.New("HTTP error code getting schema for subject " + subject + ": " + strconv.Itoa(respGetSchema.StatusCode))
+	}
+
+	var schema SchemaRegistrySchema
+	json.NewDecoder(respGetSchema.Body).Decode(&schema)
+	return &schema, nil
+}
+
+// deleteSubject soft deletes every version of a subject, then removes them for good if permanent is set
+func (c *SchemaRegistryClient) deleteSubject(subject string, permanent bool) error {
+	client := retryablehttp.NewClient()
+	requestDeleteSubject, err := c.newRequest("DELETE", "/subjects/"+url.PathEscape(subject), nil)
+	if err != nil {
+		return err
+	}
+	respDeleteSubject, err := client.Do(requestDeleteSubject)
+	if err != nil {
+		return err
+	}
+	defer respDeleteSubject.Body.Close()
+	if respDeleteSubject.StatusCode != 200 && respDeleteSubject.StatusCode != 404 {
+		return errors.New("HTTP error code deleting subject " + subject + ": " + strconv.Itoa(respDeleteSubject.StatusCode))
+	}
+	if !permanent {
+		return nil
+	}
+
+	requestHardDeleteSubject, err := c.newRequest("DELETE", "/subjects/"+url.PathEscape(subject)+"?permanent=true", nil)
+	if err != nil {
+		return err
+	}
+	respHardDeleteSubject, err := client.Do(requestHardDeleteSubject)
+	if err != nil {
+		return err
+	}
+	defer respHardDeleteSubject.Body.Close()
+	if respHardDeleteSubject.StatusCode != 200 && respHardDeleteSubject.StatusCode != 404 {
+		return errors.New("HTTP error code permanently deleting subject " + subject + ": " + strconv.Itoa(respHardDeleteSubject.StatusCode))
+	}
+	return nil
+}
+
+type SchemaRegistryCompatibility struct {
+	Compatibility      string `json:"compatibility,omitempty"`
+	CompatibilityLevel string `json:"compatibilityLevel,omitempty"`
+}
+
+type SchemaRegistryMode struct {
+	Mode string `json:"mode"`
+}
+
+type CompatibilityCheckResponse struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+// subjectPath returns the path of a global (empty subject) or subject level setting
+func subjectPath(prefix string, subject string) string {
+	if subject == "" {
+		return prefix
+	}
+	return prefix + "/" + url.PathEscape(subject)
+}
+
+// getCompatibility returns the compatibility level of a subject, or the global one if subject is empty.
+// It returns an empty string when the subject has no level of its own.
+func (c *SchemaRegistryClient) getCompatibility(subject string) (string, error) {
+	client := retryablehttp.NewClient()
+	path := subjectPath("/config", subject)
+	if subject != "" {
+		path += "?defaultToGlobal=false"
+	}
+	requestGetConfig, err := c.newRequest("GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+	respGetConfig, err := client.Do(requestGetConfig)
+	if err != nil {
+		return "", err
+	}
+	defer respGetConfig.Body.Close()
+	if respGetConfig.StatusCode == 404 {
+		return "", nil // Not Set
+	}
+	if respGetConfig.StatusCode != 200 {
+		return "", errors.New("HTTP error code getting compatibility level: " + strconv.Itoa(respGetConfig.StatusCode))
+	}
+
+	var compatibility SchemaRegistryCompatibility
+	json.NewDecoder(respGetConfig.Body).Decode(&compatibility)
+	return compatibility.CompatibilityLevel, nil
+}
+
+func (c *SchemaRegistryClient) setCompatibility(subject string, level string) error {
+	client := retryablehttp.NewClient()
+	requestSetConfig, err := c.newRequest("PUT", subjectPath("/config", subject), SchemaRegistryCompatibility{Compatibility: level})
+	if err != nil {
+		return err
+	}
+	respSetConfig, err := client.Do(requestSetConfig)
+	if err != nil {
+		return err
+	}
+	defer respSetConfig.Body.Close()
+	if respSetConfig.StatusCode != 200 {
+		return errors.New("HTTP error code setting compatibility level: " + strconv.Itoa(respSetConfig.StatusCode))
+	}
+	return nil
+}
+
+func (c *SchemaRegistryClient) deleteCompatibility(subject string) error {
+	client := retryablehttp.NewClient()
+	requestDeleteConfig, err := c.newRequest("DELETE", subjectPath("/config", subject), nil)
+	if err != nil {
+		return err
+	}
+	respDeleteConfig, err := client.Do(requestDeleteConfig)
+	if err != nil {
+		return err
+	}
+	defer respDeleteConfig.Body.Close()
+	if respDeleteConfig.StatusCode != 200 && respDeleteConfig.StatusCode != 404 {
+		return errors.New("HTTP error code deleting compatibility level: " + strconv.Itoa(respDeleteConfig.StatusCode))
+	}
+	return nil
+}
+
+// getMode returns the mode of a subject, or the global one if subject is empty.
+// It returns an empty string when the subject has no mode of its own.
+func (c *SchemaRegistryClient) getMode(subject string) (string, error) {
+	client := retryablehttp.NewClient()
+	path := subjectPath("/mode", subject)
+	if subject != "" {
+		path += "?defaultToGlobal=false"
+	}
+	requestGetMode, err := c.newRequest("GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+	respGetMode, err := client.Do(requestGetMode)
+	if err != nil {
+		return "", err
+	}
+	defer respGetMode.Body.Close()
+	if respGetMode.StatusCode == 404 {
+		return "", nil // Not Set
+	}
+	if respGetMode.StatusCode != 200 {
+		return "", errors.New("HTTP error code getting mode: " + strconv.Itoa(respGetMode.StatusCode))
+	}
+
+	var mode SchemaRegistryMode
+	json.NewDecoder(respGetMode.Body).Decode(&mode)
+	return mode.Mode, nil
+}
+
+func (c *SchemaRegistryClient) setMode(subject string, mode string) error {
+	client := retryablehttp.NewClient()
+	requestSetMode, err := c.newRequest("PUT", subjectPath("/mode", subject), SchemaRegistryMode{Mode: mode})
+	if err != nil {
+		return err
+	}
+	respSetMode, err := client.Do(requestSetMode)
+	if err != nil {
+		return err
+	}
+	defer respSetMode.Body.Close()
+	if respSetMode.StatusCode != 200 {
+		return errors.New("HTTP error code setting mode: " + strconv.Itoa(respSetMode.StatusCode))
+	}
+	return nil
+}
+
+func (c *SchemaRegistryClient) deleteMode(subject string) error {
+	client := retryablehttp.NewClient()
+	requestDeleteMode, err := c.newRequest("DELETE", subjectPath("/mode", subject), nil)
+	if err != nil {
+		return err
+	}
+	respDeleteMode, err := client.Do(requestDeleteMode)
+	if err != nil {
+		return err
+	}
+	defer respDeleteMode.Body.Close()
+	if respDeleteMode.StatusCode != 200 && respDeleteMode.StatusCode != 404 {
+		return errors.New("HTTP error code deleting mode: " + strconv.Itoa(respDeleteMode.StatusCode))
+	}
+	return nil
+}
+
+// checkCompatibility tests a schema against the latest version of a subject, returning the reasons it is incompatible
+func (c *SchemaRegistryClient) checkCompatibility(subject string, schema SchemaRegistrySchema) (bool, []string, error) {
+	client := retryablehttp.NewClient()
+	requestCheck, err := c.newRequest("POST", "/compatibility/subjects/"+url.PathEscape(subject)+"/versions/latest?verbose=true", schema)
+	if err != nil {
+		return false, nil, err
+	}
+	respCheck, err := client.Do(requestCheck)
+	if err != nil {
+		return false, nil, err
+	}
+	defer respCheck.Body.Close()
+	if respCheck.StatusCode == 404 {
+		return true, nil, nil // Nothing to be compatible with
+	}
+	if respCheck.StatusCode != 200 {
+		return false, nil, errors.New("HTTP error code checking compatibility for subject " + subject + ": " + strconv.Itoa(respCheck.StatusCode))
+	}
+
+	var CompatibilityCheckResponse CompatibilityCheckResponse
+	json.NewDecoder(respCheck.Body).Decode(&CompatibilityCheckResponse)
+	return CompatibilityCheckResponse.IsCompatible, CompatibilityCheckResponse.Messages, nil
+}
+
+func (c *SchemaRegistryClient) getSubjects() ([]string, error) {
+	client := retryablehttp.NewClient()
+	requestGetSubjects, err := c.newRequest("GET", "/subjects", nil)
+	if err != nil {
+		return nil, err
+	}
+	respGetSubjects, err := client.Do(requestGetSubjects)
+	if err != nil {
+		return nil, err
+	}
+	defer respGetSubjects.Body.Close()
+	if respGetSubjects.StatusCode != 200 {
+		return nil, errors.New("HTTP error code getting subjects: " + strconv.Itoa(respGetSubjects.StatusCode))
+	}
+
+	var subjects []string
+	json.NewDecoder(respGetSubjects.Body).Decode(&subjects)
+	return subjects, nil
+}
+
+type ConnectorTaskStatus struct {
+	Id    int    `json:"id"`
+	State string `json:"state"`
+	Trace string `json:"trace"`
+}
+
+type ConnectorStatus struct {
+	Name      string `json:"name"`
+	Connector struct {
+		State string `json:"state"`
+		Trace string `json:"trace"`
+	} `json:"connector"`
+	Tasks []ConnectorTaskStatus `json:"tasks"`
+}
+
+// Failures lists the trace of the connector and of every failed task
+func (status *ConnectorStatus) Failures() []string {
+	var failures []string
+	if status.Connector.State == "FAILED" {
+		failures = append(failures, "connector: "+status.Connector.Trace)
+	}
+	for _, task := range status.Tasks {
+		if task.State == "FAILED" {
+			failures = append(failures, "task "+strconv.Itoa(task.Id)+": "+task.Trace)
+		}
+	}
+	return failures
+}
+
+type CreateConnectorRequest struct {
+	Name   string            `json:"name"`
+	Config map[string]string `json:"config"`
+}
+
+func connectorsEndpoint(cluster Cluster) string {
+	return cluster.ApiEndpoint + "/connect/v1/environments/" + cluster.AccountId + "/clusters/" + cluster.Id + "/connectors"
+}
+
+func (c *Config) doConnectorRequest(method string, endpoint string, body interface{}, expectedStatus ...int) (*http.Response, error) {
+	var rawBody interface{}
+	if body != nil {
+		bytesRepresentation, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		rawBody = bytes.NewBuffer(bytesRepresentation)
+	}
+	client := retryablehttp.NewClient()
+	request, err := retryablehttp.NewRequest(method, endpoint, rawBody)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range expectedStatus {
+		if response.StatusCode == status {
+			return response, nil
+		}
+	}
+	defer response.Body.Close()
+	var connectError map[string]interface{}
+	json.NewDecoder(response.Body).Decode(&connectError)
+	if message, ok := connectError["message"].(string); ok {
+		return nil, errors.New("HTTP error code " + strconv.Itoa(response.StatusCode) + " on connector request " + method + " " + endpoint + ": " + message)
+	}
+	return nil, errors.New("HTTP error code " + strconv.Itoa(response.StatusCode) + " on connector request " + method + " " + endpoint)
+}
+
+func (c *Config) createConnector(cluster Cluster, name string, config map[string]string) error {
+	response, err := c.doConnectorRequest("POST", connectorsEndpoint(cluster), CreateConnectorRequest{Name: name, Config: config}, 200, 201)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (c *Config) updateConnectorConfig(cluster Cluster, name string, config map[string]string) error {
+	response, err := c.doConnectorRequest("PUT", connectorsEndpoint(cluster)+"/"+name+"/config", config, 200, 201)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+// getConnectorConfig returns the configuration of a connector, nil if it does not exist
+func (c *Config) getConnectorConfig(cluster Cluster, name string) (map[string]string, error) {
+	response, err := c.doConnectorRequest("GET", connectorsEndpoint(cluster)+"/"+name+"/config", nil, 200, 404)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == 404 {
+		return nil, nil // Not Found
+	}
+	var config map[string]string
+	json.NewDecoder(response.Body).Decode(&config)
+	return config, nil
+}
+
+func (c *Config) getConnectorStatus(cluster Cluster, name string) (*ConnectorStatus, error) {
+	response, err := c.doConnectorRequest("GET", connectorsEndpoint(cluster)+"/"+name+"/status", nil, 200)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	var status ConnectorStatus
+	json.NewDecoder(response.Body).Decode(&status)
+	return &status, nil
+}
+
+func (c *Config) pauseConnector(cluster Cluster, name string) error {
+	response, err := c.doConnectorRequest("PUT", connectorsEndpoint(cluster)+"/"+name+"/pause", nil, 200, 202, 204)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (c *Config) resumeConnector(cluster Cluster, name string) error {
+	response, err := c.doConnectorRequest("PUT", connectorsEndpoint(cluster)+"/"+name+"/resume", nil, 200, 202, 204)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (c *Config) deleteConnector(cluster Cluster, name string) error {
+	response, err := c.doConnectorRequest("DELETE", connectorsEndpoint(cluster)+"/"+name, nil, 200, 204, 404)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+type ConnectorPlugin struct {
+	Class   string `json:"class"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+}
+
+type ConnectorConfigDefinition struct {
+	Name          string      `json:"name"`
+	Type          string      `json:"type"`
+	Required      bool        `json:"required"`
+	DefaultValue  interface{} `json:"default_value"`
+	Importance    string      `json:"importance"`
+	Documentation string      `json:"documentation"`
+	Group         string      `json:"group"`
+}
+
+type ConnectorConfigValue struct {
+	Name   string      `json:"name"`
+	Value  interface{} `json:"value"`
+	Errors []string    `json:"errors"`
+}
+
+type ConnectorConfigValidation struct {
+	Name       string `json:"name"`
+	ErrorCount int    `json:"error_count"`
+	Configs    []struct {
+		Definition ConnectorConfigDefinition `json:"definition"`
+		Value      ConnectorConfigValue      `json:"value"`
+	} `json:"configs"`
+}
+
+// Errors lists every configuration error as "<key>: <message>"
+func (validation *ConnectorConfigValidation) Errors() []string {
+	var messages []string
+	for _, config := range validation.Configs {
+		for _, message := range config.Value.Errors {
+			messages = append(messages, config.Value.Name+": "+message)
+		}
+	}
+	return messages
+}
+
+func connectorPluginsEndpoint(cluster Cluster) string {
+	return cluster.ApiEndpoint + "/connect/v1/environments/" + cluster.AccountId + "/clusters/" + cluster.Id + "/connector-plugins"
+}
+
+func (c *Config) getConnectorPlugins(cluster Cluster) ([]ConnectorPlugin, error) {
+	response, err := c.doConnectorRequest("GET", connectorPluginsEndpoint(cluster), nil, 200)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	var plugins []ConnectorPlugin
+	json.NewDecoder(response.Body).Decode(&plugins)
+	return plugins, nil
+}
+
+func (c *Config) validateConnectorConfig(cluster Cluster, class string, config map[string]string) (*ConnectorConfigValidation, error) {
+	response, err := c.doConnectorRequest("PUT", connectorPluginsEndpoint(cluster)+"/"+url.PathEscape(class)+"/config/validate", config, 200)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	var validation ConnectorConfigValidation
+	json.NewDecoder(response.Body).Decode(&validation)
+	return &validation, nil
+}
+
+type KsqlCluster struct {
+	Id                       string `json:"id"`
+	Name                     string `json:"name"`
+	AccountId                string `json:"account_id"`
+	KafkaClusterId           string `json:"kafka_cluster_id"`
+	OutputTopicPrefix        string `json:"output_topic_prefix"`
+	PhysicalClusterId        string `json:"physical_cluster_id"`
+	Storage                  int    `json:"storage"`
+	Endpoint                 string `json:"endpoint"`
+	Status                   string `json:"status"`
+	TotalNumCsu              int    `json:"total_num_csu"`
+	ServiceAccountId         int    `json:"service_account_id"`
+	UseDetailedProcessingLog bool   `json:"use_detailed_processing_log"`
+	Created                  string `json:"created"`
+	Modified                 string `json:"modified"`
+}
+
+// ProcessingLogTopic is the topic where ksqlDB writes its processing log
+func (ksqlCluster *KsqlCluster) ProcessingLogTopic() string {
+	if ksqlCluster.OutputTopicPrefix != "" {
+		return ksqlCluster.OutputTopicPrefix + "processing-log"
+	}
+	return ksqlCluster.PhysicalClusterId + "processing-log"
+}
+
+type KsqlClusterResponse struct {
+	Error            interface{} `json:"error"`
+	ValidationErrors interface{} `json:"validation_errors"`
+	Cluster          KsqlCluster `json:"cluster"`
+}
+
+func (c *Config) createKsqlCluster(accountId string, kafkaClusterId string, name string, csu int, serviceAccountId int, useDetailedProcessingLog bool) (*KsqlCluster, error) {
+	createKsqlClusterRequest := map[string]map[string]interface{}{
+		"config": {
+			"name":                        name,
+			"account_id":                  accountId,
+			"kafka_cluster_id":            kafkaClusterId,
+			"total_num_csu":               csu,
+			"service_account_id":          serviceAccountId,
+			"use_detailed_processing_log": useDetailedProcessingLog,
+		},
+	}
+	bytesRepresentation, err := json.Marshal(createKsqlClusterRequest)
+	if err != nil {
+		return nil, err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestCreateKsqlCluster, err := retryablehttp.NewRequest("POST", c.ApiEndpoint+"/api/ksqls", bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return nil, err
+	}
+	requestCreateKsqlCluster.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestCreateKsqlCluster.Header.Set("Content-Type", "application/json")
+	responseCreateKsqlCluster, err := client.Do(requestCreateKsqlCluster)
+	if err != nil {
+		return nil, err
+	}
+	defer responseCreateKsqlCluster.Body.Close()
+
+	if responseCreateKsqlCluster.StatusCode != 200 && responseCreateKsqlCluster.StatusCode != 201 {
+		return nil, errors.New("HTTP error code creating ksqlDB cluster : " + strconv.Itoa(responseCreateKsqlCluster.StatusCode))
+	}
+
+	var CreateKsqlClusterResponse KsqlClusterResponse
+	json.NewDecoder(responseCreateKsqlCluster.Body).Decode(&CreateKsqlClusterResponse)
+	return &CreateKsqlClusterResponse.Cluster, nil
+}
+
+// getKsqlCluster returns a ksqlDB cluster, nil if it does not exist
+func (c *Config) getKsqlCluster(accountId string, ksqlClusterId string) (*KsqlCluster, error) {
+	client := retryablehttp.NewClient()
+	requestKsqlCluster, err := retryablehttp.NewRequest("GET", c.ApiEndpoint+"/api/ksqls/"+ksqlClusterId+"?account_id="+accountId, nil)
+	if err != nil {
+		return nil, err
+	}
+	requestKsqlCluster.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	respKsqlCluster, err := client.Do(requestKsqlCluster)
+	if err != nil {
+		return nil, err
+	}
+	defer respKsqlCluster.Body.Close()
+	if respKsqlCluster.StatusCode == 404 {
+		return nil, nil // Not Found
+	}
+	if respKsqlCluster.StatusCode != 200 {
+		return nil, errors.New("HTTP error code getting ksqlDB cluster: " + strconv.Itoa(respKsqlCluster.StatusCode))
+	}
+
+	var KsqlClusterResponse KsqlClusterResponse
+	json.NewDecoder(respKsqlCluster.Body).Decode(&KsqlClusterResponse)
+	return &KsqlClusterResponse.Cluster, nil
+}
+
+func (c *Config) deleteKsqlCluster(ksqlCluster KsqlCluster) error {
+	deleteKsqlClusterRequest := map[string]map[string]interface{}{
+		"cluster": {
+			"id":         ksqlCluster.Id,
+			"account_id": ksqlCluster.AccountId,
+		},
+	}
+	bytesRepresentation, err := json.Marshal(deleteKsqlClusterRequest)
+	if err != nil {
+		return err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	client := retryablehttp.NewClient()
+	requestDeleteKsqlCluster, err := retryablehttp.NewRequest("DELETE", c.ApiEndpoint+"/api/ksqls/"+ksqlCluster.Id, bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return err
+	}
+	requestDeleteKsqlCluster.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	requestDeleteKsqlCluster.Header.Set("Content-Type", "application/json")
+	responseDeleteKsqlCluster, err := client.Do(requestDeleteKsqlCluster)
+	if err != nil {
+		return err
+	}
+	defer responseDeleteKsqlCluster.Body.Close()
+
+	if responseDeleteKsqlCluster.StatusCode != 200 && responseDeleteKsqlCluster.StatusCode != 204 {
+		return errors.New("HTTP error code deleting ksqlDB cluster : " + strconv.Itoa(responseDeleteKsqlCluster.StatusCode))
+	}
+
+	return nil
+}
+
+// KsqlClient talks to the REST endpoint of a ksqlDB application with a ksqlDB API key
+type KsqlClient struct {
+	Endpoint  string
+	ApiKey    string
+	ApiSecret string
+}
+
+type KsqlRequest struct {
+	Ksql              string            `json:"ksql"`
+	StreamsProperties map[string]string `json:"streamsProperties"`
+}
+
+type KsqlError struct {
+	Type      string `json:"@type"`
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+type KsqlQuery struct {
+	Id string `json:"id"`
+}
+
+type KsqlEntity struct {
+	Type          string `json:"@type"`
+	StatementText string `json:"statementText"`
+	CommandStatus struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		QueryId string `json:"queryId"`
+	} `json:"commandStatus"`
+	SourceDescription struct {
+		Name         string      `json:"name"`
+		Type         string      `json:"type"`
+		Topic        string      `json:"topic"`
+		Statement    string      `json:"statement"`
+		ReadQueries  []KsqlQuery `json:"readQueries"`
+		WriteQueries []KsqlQuery `json:"writeQueries"`
+	} `json:"sourceDescription"`
+}
+
+// ksqlSourceNotFound matches the messages of DESCRIBE and DROP on a stream or table that does not exist.
+// ksqlDB returns the generic bad statement error code 40001 for them, like for syntax or permission errors.
+var ksqlSourceNotFound = regexp.MustCompile("^(Could not find STREAM/TABLE '.*' in the Metastore|Source .* does not exist)")
+
+// SourceNotFound returns true when the statement failed because the stream or table does not exist
+func (e *KsqlError) SourceNotFound() bool {
+	return ksqlSourceNotFound.MatchString(e.Message)
+}
+
+// executeStatement runs statements on the /ksql endpoint, the KsqlError is set when ksqlDB rejects them
+func (c *KsqlClient) executeStatement(statement string, properties map[string]string) ([]KsqlEntity, *KsqlError, error) {
+	bytesRepresentation, err := json.Marshal(KsqlRequest{Ksql: statement, StreamsProperties: properties})
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Print(bytes.NewBuffer(bytesRepresentation).String())
+	// No retries: CREATE, DROP and TERMINATE are not idempotent
+	client := http.Client{}
+	requestKsql, err := http.NewRequest("POST", strings.TrimSuffix(c.Endpoint, "/")+"/ksql", bytes.NewBuffer(bytesRepresentation))
+	if err != nil {
+		return nil, nil, err
+	}
+	requestKsql.SetBasicAuth(c.ApiKey, c.ApiSecret)
+	requestKsql.Header.Set("Content-Type", "application/vnd.ksql.v1+json; charset=utf-8")
+	requestKsql.Header.Set("Accept", "application/vnd.ksql.v1+json")
+	respKsql, err := client.Do(requestKsql)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer respKsql.Body.Close()
+	if respKsql.StatusCode == 400 {
+		var ksqlError KsqlError
+		json.NewDecoder(respKsql.Body).Decode(&ksqlError)
+		return nil, &ksqlError, nil
+	}
+	if respKsql.StatusCode != 200 {
+		return nil, nil, errors.New("HTTP error code executing ksqlDB statement: " + strconv.Itoa(respKsql.StatusCode))
+	}
+
+	var entities []KsqlEntity
+	json.NewDecoder(respKsql.Body).Decode(&entities)
+	return entities, nil, nil
+}
+
+type ConsumerGroup struct {
+	ConsumerGroupId   string `json:"consumer_group_id"`
+	IsSimple          bool   `json:"is_simple"`
+	PartitionAssignor string `json:"partition_assignor"`
+	State             string `json:"state"`
+}
+
+type ConsumerGroupsResponse struct {
+	Data []ConsumerGroup `json:"data"`
+}
+
+type Consumer struct {
+	ConsumerId string `json:"consumer_id"`
+	InstanceId string `json:"instance_id"`
+	ClientId   string `json:"client_id"`
+}
+
+type ConsumersResponse struct {
+	Data []Consumer `json:"data"`
+}
+
+type ConsumerLag struct {
+	TopicName     string `json:"topic_name"`
+	PartitionId   int    `json:"partition_id"`
+	CurrentOffset int64  `json:"current_offset"`
+	LogEndOffset  int64  `json:"log_end_offset"`
+	Lag           int64  `json:"lag"`
+	ConsumerId    string `json:"consumer_id"`
+	ClientId      string `json:"client_id"`
+}
+
+type ConsumerLagsResponse struct {
+	Data []ConsumerLag `json:"data"`
+}
+
+func kafkaRestEndpoint(cluster Cluster) string {
+	return cluster.ApiEndpoint + "/kafka/v3/clusters/" + cluster.Id
+}
+
+// kafkaRestRequest calls the cluster REST API with the access token used for topics and decodes the response in result.
+// It returns the status code so that callers can handle a 404.
+func (c *Config) kafkaRestRequest(cluster Cluster, method string, path string, body interface{}, result interface{}) (int, error) {
+	return c.kafkaRestRequestAs(cluster, "", "", method, path, body, result)
+}
+
+// kafkaRestRequestAs is kafkaRestRequest authenticated with a cluster API key, or with the access token when apiKey is empty
+func (c *Config) kafkaRestRequestAs(cluster Cluster, apiKey string, apiSecret string, method string, path string, body interface{}, result interface{}) (int, error) {
+	return c.restRequest(kafkaRestEndpoint(cluster), apiKey, apiSecret, method, path, body, result)
+}
+
+// restRequest calls a JSON API under endpoint, see kafkaRestRequestAs for authentication and status handling
+func (c *Config) restRequest(endpoint string, apiKey string, apiSecret string, method string, path string, body interface{}, result interface{}) (int, error) {
+	var rawBody interface{}
+	if body != nil {
+		bytesRepresentation, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		// Not logged, cluster link configs carry the source cluster secret
+		rawBody = bytes.NewBuffer(bytesRepresentation)
+	}
+	client := retryablehttp.NewClient()
+	request, err := retryablehttp.NewRequest(method, endpoint+path, rawBody)
+	if err != nil {
+		return 0, err
+	}
+	if apiKey != "" {
+		request.SetBasicAuth(apiKey, apiSecret)
+	} else {
+		request.Header.Set("Authorization", "Bearer "+c.AccessToken.Token)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == 404 {
+		return response.StatusCode, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var restError map[string]interface{}
+		json.NewDecoder(response.Body).Decode(&restError)
+		if message, ok := restError["message"].(string); ok {
+			return response.StatusCode, errors.New("HTTP error code " + strconv.Itoa(response.StatusCode) + " on " + method + " " + path + ": " + message)
+		}
+		return response.StatusCode, errors.New("HTTP error code " + strconv.Itoa(response.StatusCode) + " on " + method + " " + path)
+	}
+	if result != nil {
+		json.NewDecoder(response.Body).Decode(result)
+	}
+	return response.StatusCode, nil
+}
+
+func (c *Config) getConsumerGroups(cluster Cluster) ([]ConsumerGroup, error) {
+	var consumerGroups ConsumerGroupsResponse
+	if _, err := c.kafkaRestRequest(cluster, "GET", "/consumer-groups", nil, &consumerGroups); err != nil {
+		return nil, err
+	}
+	return consumerGroups.Data, nil
+}
+
+func (c *Config) getConsumers(cluster Cluster, groupId string) ([]Consumer, error) {
+	var consumers ConsumersResponse
+	if _, err := c.kafkaRestRequest(cluster, "GET", "/consumer-groups/"+url.PathEscape(groupId)+"/consumers", nil, &consumers); err != nil {
+		return nil, err
+	}
+	return consumers.Data, nil
+}
+
+// getConsumerLags returns the committed offsets and lag of a group for every partition it consumes
+func (c *Config) getConsumerLags(cluster Cluster, groupId string) ([]ConsumerLag, error) {
+	var lags ConsumerLagsResponse
+	status, err := c.kafkaRestRequest(cluster, "GET", "/consumer-groups/"+url.PathEscape(groupId)+"/lags", nil, &lags)
+	if err != nil {
+		return nil, err
+	}
+	if status == 404 {
+		return nil, errors.New("Unable to find consumer group " + groupId + " in cluster " + cluster.Name)
+	}
+	return lags.Data, nil
+}
+
+type PartitionOffset struct {
+	Partition int   `json:"partition"`
+	Offset    int64 `json:"offset"`
+}
+
+// ResetOffsetsRequest describes where to move the offsets: earliest, latest, timestamp (milliseconds) or explicit offsets
+type ResetOffsetsRequest struct {
+	Strategy  string
+	Timestamp int64
+	Offsets   []PartitionOffset
+}
+
+func kafkaClientConfig() *sarama.Config {
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Version = sarama.V2_0_0_0
+	kafkaConfig.ClientID = "terraform-provider-confluent"
+	kafkaConfig.Consumer.Return.Errors = true
+	kafkaConfig.Consumer.Offsets.AutoCommit.Enable = false
+	return kafkaConfig
+}
+
+// kafkaClient connects to the cluster with an API key. The REST API cannot commit offsets, so this goes through the Kafka protocol.
+func kafkaClient(cluster Cluster, apiKey string, apiSecret string) (sarama.Client, error) {
+	kafkaConfig := kafkaClientConfig()
+	kafkaConfig.Net.TLS.Enable = true
+	kafkaConfig.Net.SASL.Enable = true
+	kafkaConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	kafkaConfig.Net.SASL.User = apiKey
+	kafkaConfig.Net.SASL.Password = apiSecret
+	return sarama.NewClient([]string{cluster.Host() + ":" + strconv.Itoa(cluster.Port())}, kafkaConfig)
+}
+
+// resetOffsetTargets resolves the offset of every partition to reset
+func resetOffsetTargets(client sarama.Client, topic string, reset ResetOffsetsRequest) ([]PartitionOffset, error) {
+	if reset.Strategy == "explicit" {
+		return reset.Offsets, nil
+	}
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+	var targets []PartitionOffset
+	for _, partition := range partitions {
+		var offset int64
+		switch reset.Strategy {
+		case "earliest":
+			offset, err = client.GetOffset(topic, partition, sarama.OffsetOldest)
+		case "latest":
+			offset, err = client.GetOffset(topic, partition, sarama.OffsetNewest)
+		case "timestamp":
+			offset, err = client.GetOffset(topic, partition, reset.Timestamp)
+			if err == nil && offset < 0 {
+				// No message after the timestamp
+				offset, err = client.GetOffset(topic, partition, sarama.OffsetNewest)
+			}
+		default:
+			return nil, errors.New("Unknown offset reset strategy " + reset.Strategy)
+		}
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, PartitionOffset{Partition: int(partition), Offset: offset})
+	}
+	return targets, nil
+}
+
+// resetConsumerGroupOffsets commits new offsets for a group on a topic and returns the offsets applied.
+// Kafka only accepts the commit while the group has no active member.
+func resetConsumerGroupOffsets(cluster Cluster, apiKey string, apiSecret string, groupId string, topic string, reset ResetOffsetsRequest) ([]PartitionOffset, error) {
+	client, err := kafkaClient(cluster, apiKey, apiSecret)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return commitOffsets(client, groupId, topic, reset)
+}
+
+func commitOffsets(client sarama.Client, groupId string, topic string, reset ResetOffsetsRequest) ([]PartitionOffset, error) {
+	targets, err := resetOffsetTargets(client, topic, reset)
+	if err != nil {
+		return nil, err
+	}
+	offsetManager, err := sarama.NewOffsetManagerFromClient(groupId, client)
+	if err != nil {
+		return nil, err
+	}
+	var partitionManagers []sarama.PartitionOffsetManager
+	for _, target := range targets {
+		partitionManager, err := offsetManager.ManagePartition(topic, int32(target.Partition))
+		if err != nil {
+			offsetManager.Close()
+			return nil, err
+		}
+		// MarkOffset only moves forward and ResetOffset only backward
+		partitionManager.MarkOffset(target.Offset, "")
+		partitionManager.ResetOffset(target.Offset, "")
+		partitionManager.AsyncClose()
+		partitionManagers = append(partitionManagers, partitionManager)
+	}
+	offsetManager.Close()
+
+	var messages []string
+	for _, partitionManager := range partitionManagers {
+		for consumerError := range partitionManager.Errors() {
+			messages = append(messages, consumerError.Error())
+		}
+	}
+	if len(messages) > 0 {
+		return nil, errors.New("Unable to commit offsets of consumer group " + groupId + ":\n" + strings.Join(messages, "\n"))
+	}
+	return targets, nil
+}
+
+// getCommittedOffsets returns the offsets committed by a group on a topic, asked to the group coordinator
+func getCommittedOffsets(cluster Cluster, apiKey string, apiSecret string, groupId string, topic string) ([]PartitionOffset, error) {
+	client, err := kafkaClient(cluster, apiKey, apiSecret)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return fetchCommittedOffsets(client, groupId, topic)
+}
+
+// fetchCommittedOffsets leaves out the partitions without a committed offset
+func fetchCommittedOffsets(client sarama.Client, groupId string, topic string) ([]PartitionOffset, error) {
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+	coordinator, err := client.Coordinator(groupId)
+	if err != nil {
+		return nil, err
+	}
+	request := &sarama.OffsetFetchRequest{Version: 1, ConsumerGroup: groupId}
+	for _, partition := range partitions {
+		request.AddPartition(topic, partition)
+	}
+	response, err := coordinator.FetchOffset(request)
+	if err != nil {
+		return nil, err
+	}
+	var offsets []PartitionOffset
+	for _, partition := range partitions {
+		block := response.GetBlock(topic, partition)
+		if block == nil {
+			continue
+		}
+		if block.Err != sarama.ErrNoError {
+			return nil, block.Err
+		}
+		if block.Offset < 0 {
+			continue
+		}
+		offsets = append(offsets, PartitionOffset{Partition: int(partition), Offset: block.Offset})
+	}
+	return offsets, nil
+}
+
+type ClientQuotaReference struct {
+	Id string `json:"id"`
+}
+
+// ClientQuotaThroughput holds byte rates as strings, the API requires both of them
+type ClientQuotaThroughput struct {
+	IngressByteRate string `json:"ingress_byte_rate"`
+	EgressByteRate  string `json:"egress_byte_rate"`
+}
+
+type ClientQuotaSpec struct {
+	DisplayName string                 `json:"display_name"`
+	Description string                 `json:"description"`
+	Throughput  ClientQuotaThroughput  `json:"throughput"`
+	Cluster     ClientQuotaReference   `json:"cluster"`
+	Principals  []ClientQuotaReference `json:"principals"`
+	Environment ClientQuotaReference   `json:"environment"`
+}
+
+type ClientQuota struct {
+	Id   string          `json:"id,omitempty"`
+	Spec ClientQuotaSpec `json:"spec"`
+}
+
+// clientQuotaRequest calls the Confluent Cloud Kafka quotas API, which only accepts a Cloud API key
+func (c *Config) clientQuotaRequest(method string, path string, body interface{}, result interface{}) (int, error) {
+	if c.CloudApiKey == "" {
+		return 0, errors.New("cloud_api_key and cloud_api_secret must be set on the provider to manage client quotas")
+	}
+	return c.restRequest(c.CloudApiEndpoint+"/kafka-quotas/v1/client-quotas", c.CloudApiKey, c.CloudApiSecret, method, path, body, result)
+}
+
+// getClientQuota returns nil when the quota does not exist anymore
+func (c *Config) getClientQuota(quotaId string) (*ClientQuota, error) {
+	var quota ClientQuota
+	status, err := c.clientQuotaRequest("GET", "/"+url.PathEscape(quotaId), nil, &quota)
+	if err != nil {
+		return nil, err
+	}
+	if status == 404 {
+		return nil, nil
+	}
+	return &quota, nil
+}
+
+func (c *Config) createClientQuota(spec ClientQuotaSpec) (*ClientQuota, error) {
+	var quota ClientQuota
+	if _, err := c.clientQuotaRequest("POST", "", ClientQuota{Spec: spec}, &quota); err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// updateClientQuota sends the whole spec, cluster and environment cannot change
+func (c *Config) updateClientQuota(quotaId string, spec ClientQuotaSpec) error {
+	_, err := c.clientQuotaRequest("PATCH", "/"+url.PathEscape(quotaId), ClientQuota{Spec: spec}, nil)
+	return err
+}
+
+func (c *Config) deleteClientQuota(quotaId string) error {
+	_, err := c.clientQuotaRequest("DELETE", "/"+url.PathEscape(quotaId), nil, nil)
+	return err
+}
+
+type KafkaConfig struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type KafkaConfigsResponse struct {
+	Data []KafkaConfig `json:"data"`
+}
+
+type ClusterLink struct {
+	LinkName        string `json:"link_name"`
+	LinkId          string `json:"link_id"`
+	SourceClusterId string `json:"source_cluster_id"`
+}
+
+type CreateClusterLinkRequest struct {
+	SourceClusterId string        `json:"source_cluster_id"`
+	Configs         []KafkaConfig `json:"configs"`
+}
+
+type MirrorTopic struct {
+	LinkName        string `json:"link_name"`
+	MirrorTopicName string `json:"mirror_topic_name"`
+	SourceTopicName string `json:"source_topic_name"`
+	MirrorStatus    string `json:"mirror_status"`
+}
+
+// ClusterLinkCredentials authenticates cluster link calls on the destination cluster, the access token is used when ApiKey is empty
+type ClusterLinkCredentials struct {
+	ApiKey    string
+	ApiSecret string
+}
+
+func clusterLinkPath(linkName string) string {
+	return "/links/" + url.PathEscape(linkName)
+}
+
+func (c *Config) createClusterLink(cluster Cluster, credentials ClusterLinkCredentials, linkName string, link CreateClusterLinkRequest) error {
+	_, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "POST", "/links?link_name="+url.QueryEscape(linkName), link, nil)
+	return err
+}
+
+// getClusterLink returns nil when the link does not exist on the destination cluster
+func (c *Config) getClusterLink(cluster Cluster, credentials ClusterLinkCredentials, linkName string) (*ClusterLink, error) {
+	var link ClusterLink
+	status, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "GET", clusterLinkPath(linkName), nil, &link)
+	if err != nil {
+		return nil, err
+	}
+	if status == 404 {
+		return nil, nil
+	}
+	return &link, nil
+}
+
+func (c *Config) getClusterLinkConfigs(cluster Cluster, credentials ClusterLinkCredentials, linkName string) ([]KafkaConfig, error) {
+	var configs KafkaConfigsResponse
+	if _, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "GET", clusterLinkPath(linkName)+"/configs", nil, &configs); err != nil {
+		return nil, err
+	}
+	return configs.Data, nil
+}
+
+func (c *Config) updateClusterLinkConfigs(cluster Cluster, credentials ClusterLinkCredentials, linkName string, configs []KafkaConfig) error {
+	body := map[string]interface{}{"data": configs}
+	_, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "PUT", clusterLinkPath(linkName)+"/configs:alter", body, nil)
+	return err
+}
+
+func (c *Config) resetClusterLinkConfig(cluster Cluster, credentials ClusterLinkCredentials, linkName string, name string) error {
+	_, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "DELETE", clusterLinkPath(linkName)+"/configs/"+url.PathEscape(name), nil, nil)
+	return err
+}
+
+func (c *Config) deleteClusterLink(cluster Cluster, credentials ClusterLinkCredentials, linkName string) error {
+	_, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "DELETE", clusterLinkPath(linkName), nil, nil)
+	return err
+}
+
+func (c *Config) createMirrorTopic(cluster Cluster, credentials ClusterLinkCredentials, linkName string, sourceTopic string) error {
+	body := map[string]string{"source_topic_name": sourceTopic}
+	_, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "POST", clusterLinkPath(linkName)+"/mirrors", body, nil)
+	return err
+}
+
+// getMirrorTopic returns nil when the topic is not mirrored by the link
+func (c *Config) getMirrorTopic(cluster Cluster, credentials ClusterLinkCredentials, linkName string, topic string) (*MirrorTopic, error) {
+	var mirror MirrorTopic
+	status, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "GET", clusterLinkPath(linkName)+"/mirrors/"+url.PathEscape(topic), nil, &mirror)
+	if err != nil {
+		return nil, err
+	}
+	if status == 404 {
+		return nil, nil
+	}
+	return &mirror, nil
+}
+
+// alterMirrorTopic runs one of the pause, resume, promote or failover actions on a mirror topic
+func (c *Config) alterMirrorTopic(cluster Cluster, credentials ClusterLinkCredentials, linkName string, topic string, action string) error {
+	body := map[string][]string{"mirror_topic_names": []string{topic}}
+	_, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "POST", clusterLinkPath(linkName)+"/mirrors:"+action, body, nil)
+	return err
+}
+
+// mirroredTopicExists looks the topic up in the destination cluster, once promoted or failed over it is no longer listed as a mirror
+func (c *Config) mirroredTopicExists(cluster Cluster, credentials ClusterLinkCredentials, topic string) (bool, error) {
+	status, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "GET", "/topics/"+url.PathEscape(topic), nil, nil)
+	if err != nil {
+		return false, err
+	}
+	return status != 404, nil
+}
+
+func (c *Config) deleteMirrorTopic(cluster Cluster, credentials ClusterLinkCredentials, topic string) error {
+	_, err := c.kafkaRestRequestAs(cluster, credentials.ApiKey, credentials.ApiSecret, "DELETE", "/topics/"+url.PathEscape(topic), nil, nil)
+	return err
+}
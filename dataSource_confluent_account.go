@@ -11,10 +11,18 @@ func dataSourceConfluentAccount() *schema.Resource {
 		Read: dataSourceAccountRead,
 
 		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				ForceNew: true,
-				Optional: true,
+				Type:          schema.TypeString,
+				ForceNew:      true,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
 			},
 			"deactivated": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -36,32 +44,52 @@ func dataSourceConfluentAccount() *schema.Resource {
 	}
 }
 
+func setAccountData(d *schema.ResourceData, account Account) {
+	d.SetId(account.Id)
+	d.Set("name", account.Name)
+	d.Set("deactivated", account.Deactivated)
+	d.Set("created", account.Created)
+	d.Set("modified", account.Modified)
+	d.Set("internal", account.Internal)
+}
+
 func dataSourceAccountRead(d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	if err := config.connect(); err != nil {
 		return err
 	}
+	accountId, accountIdOk := d.GetOk("id")
 	accountName, accountNameOk := d.GetOk("name")
 
+	if accountIdOk {
+		log.Print("Reading account with ID: " + accountId.(string))
+		for _, account := range config.Me.Accounts {
+			if account.Id == accountId.(string) {
+				setAccountData(d, account)
+				return nil
+			}
+		}
+		// Accounts created after the provider connected are not in Me.Accounts
+		account, err := config.getAccount(accountId.(string))
+		if err != nil {
+			return err
+		}
+		if account == nil {
+			return errors.New("Unable to find account with ID " + accountId.(string))
+		}
+		setAccountData(d, *account)
+		return nil
+	}
+
 	if ! accountNameOk {
-		log.Printf("Reading default account: " + config.Me.Account.Name)
-		d.SetId(config.Me.Account.Id)
-		d.Set("name", config.Me.Account.Name)
-		d.Set("deactivated", config.Me.Account.Deactivated)
-		d.Set("created", config.Me.Account.Created)
-		d.Set("modified", config.Me.Account.Modified)
-		d.Set("internal", config.Me.Account.Internal)
+		log.Print("Reading default account: " + config.Me.Account.Name)
+		setAccountData(d, config.Me.Account)
 		return nil
 	}
-	log.Printf("Reading account: " + accountName.(string))
+	log.Print("Reading account: " + accountName.(string))
 	for _, account := range config.Me.Accounts {
 		if account.Name == accountName.(string) {
-			d.SetId(account.Id)
-			d.Set("name", account.Name)
-			d.Set("deactivated", account.Deactivated)
-			d.Set("created", account.Created)
-			d.Set("modified", account.Modified)
-			d.Set("internal", account.Internal)
+			setAccountData(d, account)
 			return nil
 		}
 	}
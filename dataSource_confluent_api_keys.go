@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+func dataSourceConfluentApiKeys() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApiKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Account ID. If not set, using default account",
+			},
+			"cluster_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list keys scoped to this cluster",
+			},
+			"user_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list keys owned by this user or service account",
+			},
+			"service_account": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list keys owned (or not) by a service account",
+			},
+			"deactivated": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list deactivated (or active) keys",
+			},
+			"description_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegex,
+				Description:  "Only list keys whose description matches this regular expression",
+			},
+			"created_before": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+				Description:  "Only list keys created before this RFC3339 date",
+			},
+			"created_after": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+				Description:  "Only list keys created after this RFC3339 date",
+			},
+			"api_keys": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"service_account": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"deactivated": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"resource_ids": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"created": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"modified": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateRegex(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		errs = append(errs, errors.New(k+": "+err.Error()))
+	}
+	return
+}
+
+func validateRFC3339(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		errs = append(errs, errors.New(k+": "+err.Error()))
+	}
+	return
+}
+
+func dataSourceApiKeysRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	log.Print("Reading API Keys for account " + accountId.(string) + " and cluster " + clusterId)
+
+	apiKeys, err := config.getApiKeys(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+
+	descriptionRegex := regexp.MustCompile(d.Get("description_regex").(string))
+	userId, userIdSet := d.GetOk("user_id")
+	serviceAccount, serviceAccountSet := d.GetOkExists("service_account")
+	deactivated, deactivatedSet := d.GetOkExists("deactivated")
+	createdBefore, createdBeforeSet := d.GetOk("created_before")
+	createdAfter, createdAfterSet := d.GetOk("created_after")
+
+	var result []map[string]interface{}
+	for _, apiKey := range apiKeys {
+		if userIdSet && apiKey.UserId != userId.(int) {
+			continue
+		}
+		if serviceAccountSet && apiKey.ServiceAccount != serviceAccount.(bool) {
+			continue
+		}
+		if deactivatedSet && apiKey.Deactivated != deactivated.(bool) {
+			continue
+		}
+		if !descriptionRegex.MatchString(apiKey.Description) {
+			continue
+		}
+		if createdBeforeSet || createdAfterSet {
+			created, err := time.Parse(time.RFC3339, apiKey.Created)
+			if err != nil {
+				return errors.New("Unable to parse creation date of API Key " + strconv.Itoa(apiKey.Id) + ": " + err.Error())
+			}
+			if createdBeforeSet && !created.Before(mustParseRFC3339(createdBefore.(string))) {
+				continue
+			}
+			if createdAfterSet && !created.After(mustParseRFC3339(createdAfter.(string))) {
+				continue
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"id":              strconv.Itoa(apiKey.Id),
+			"key":             apiKey.Key,
+			"description":     apiKey.Description,
+			"user_id":         apiKey.UserId,
+			"service_account": apiKey.ServiceAccount,
+			"deactivated":     apiKey.Deactivated,
+			"resource_ids":    apiKey.ResourceIds(),
+			"created":         apiKey.Created,
+			"modified":        apiKey.Modified,
+		})
+	}
+
+	d.Set("account_id", accountId.(string))
+	if err := d.Set("api_keys", result); err != nil {
+		return err
+	}
+	if clusterId != "" {
+		d.SetId(accountId.(string) + "-" + clusterId)
+	} else {
+		d.SetId(accountId.(string))
+	}
+	return nil
+}
+
+// mustParseRFC3339 parses a date already checked by validateRFC3339
+func mustParseRFC3339(value string) time.Time {
+	parsed, _ := time.Parse(time.RFC3339, value)
+	return parsed
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+	"strconv"
 )
 
 func dataSourceConfluentCluster() *schema.Resource {
@@ -10,11 +12,20 @@ func dataSourceConfluentCluster() *schema.Resource {
 		Read: dataSourceClusterRead,
 
 		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+				Description:   "Cluster ID",
+			},
 			"name": &schema.Schema{
-				Type:        schema.TypeString,
-				ForceNew:    true,
-				Required:    true,
-				Description: "Cluster name",
+				Type:          schema.TypeString,
+				ForceNew:      true,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "Cluster name",
 			},
 			"account_id": &schema.Schema{
 				Type:        schema.TypeString,
@@ -26,6 +37,78 @@ func dataSourceConfluentCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"service_provider": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dedicated": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enterprise": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"storage": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"network_ingress": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"network_egress": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"physical_cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"k8s_cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_sla_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_schedulable": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"legacy_endpoint": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"internal_proxy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"price_per_hour": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"accrued_this_cycle": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"modified": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"endpoint": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -67,15 +150,45 @@ func dataSourceClusterRead(d *schema.ResourceData, m interface{}) error {
 	if !accountIdSet {
 		accountId = config.Me.Account.Id
 	}
-	log.Printf("Reading cluster: " + d.Get("name").(string) + " and account " + accountId.(string))
-	cluster, err := config.getCluster(accountId.(string), d.Get("name").(string))
+	clusterId, clusterIdSet := d.GetOk("id")
+	clusterName, clusterNameSet := d.GetOk("name")
+
+	var cluster *Cluster
+	var err error
+	if clusterIdSet {
+		log.Print("Reading cluster with ID: " + clusterId.(string) + " and account " + accountId.(string))
+		cluster, err = config.getClusterPerAccount(accountId.(string), clusterId.(string))
+	} else if clusterNameSet {
+		log.Print("Reading cluster: " + clusterName.(string) + " and account " + accountId.(string))
+		cluster, err = config.getCluster(accountId.(string), clusterName.(string))
+	} else {
+		return errors.New("One of id or name must be set to read a cluster")
+	}
 	if err != nil {
-		d.SetId("")
-		return nil
+		return err
 	}
 
 	d.Set("name", cluster.Name)
 	d.Set("account_id", accountId.(string))
+	d.Set("organization_id", strconv.Itoa(cluster.OrganizationId))
+	d.Set("service_provider", cluster.ServiceProvider)
+	d.Set("region", cluster.Region)
+	d.Set("type", cluster.Type)
+	d.Set("dedicated", cluster.Dedicated)
+	d.Set("enterprise", cluster.Enterprise)
+	d.Set("storage", cluster.Storage)
+	d.Set("network_ingress", cluster.NetworkIngress)
+	d.Set("network_egress", cluster.NetworkEgress)
+	d.Set("physical_cluster_id", cluster.PhysicalClusterId)
+	d.Set("k8s_cluster_id", cluster.K8SClusterId)
+	d.Set("is_sla_enabled", cluster.IsSlaEnabled)
+	d.Set("is_schedulable", cluster.IsSchedulable)
+	d.Set("legacy_endpoint", cluster.LegacyEndpoint)
+	d.Set("internal_proxy", cluster.InternalProxy)
+	d.Set("price_per_hour", cluster.PricePerHour)
+	d.Set("accrued_this_cycle", cluster.AccruedThisCycle)
+	d.Set("created", cluster.Created)
+	d.Set("modified", cluster.Modified)
 	d.Set("endpoint", cluster.Endpoint)
 	d.Set("api_endpoint", cluster.ApiEndpoint)
 	d.Set("status", cluster.Status)
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"regexp"
+	"strings"
+)
+
+func dataSourceConfluentClusters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceClustersRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Accounts to search. If not set, using default account",
+			},
+			"service_provider": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"durability": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegex,
+			},
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"clusters": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"account_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_provider": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"durability": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"endpoint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"api_endpoint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// matchesFilter returns true when the filter is not set or equals the value, ignoring case
+func matchesFilter(d *schema.ResourceData, key string, value string) bool {
+	filter, filterSet := d.GetOk(key)
+	return !filterSet || strings.EqualFold(filter.(string), value)
+}
+
+func dataSourceClustersRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	accountIds := []string{config.Me.Account.Id}
+	if v, ok := d.GetOk("account_ids"); ok {
+		accountIds = []string{}
+		for _, accountId := range v.([]interface{}) {
+			accountIds = append(accountIds, accountId.(string))
+		}
+	}
+	nameRegex := regexp.MustCompile(d.Get("name_regex").(string))
+
+	ids := []string{}
+	var result []map[string]interface{}
+	for _, accountId := range accountIds {
+		log.Print("Reading clusters for account " + accountId)
+		clusters, err := config.getClustersPerAccount(accountId)
+		if err != nil {
+			return err
+		}
+		for _, cluster := range clusters.Clusters {
+			if !nameRegex.MatchString(cluster.Name) ||
+				!matchesFilter(d, "service_provider", cluster.ServiceProvider) ||
+				!matchesFilter(d, "region", cluster.Region) ||
+				!matchesFilter(d, "durability", cluster.Durability) ||
+				!matchesFilter(d, "type", cluster.Type) ||
+				!matchesFilter(d, "status", cluster.Status) {
+				continue
+			}
+			ids = append(ids, cluster.Id)
+			result = append(result, map[string]interface{}{
+				"id":               cluster.Id,
+				"name":             cluster.Name,
+				"account_id":       accountId,
+				"service_provider": cluster.ServiceProvider,
+				"region":           cluster.Region,
+				"durability":       cluster.Durability,
+				"type":             cluster.Type,
+				"status":           cluster.Status,
+				"endpoint":         cluster.Endpoint,
+				"api_endpoint":     cluster.ApiEndpoint,
+				"host":             cluster.Host(),
+				"port":             cluster.Port(),
+				"protocol":         cluster.Protocol(),
+			})
+		}
+	}
+
+	d.Set("ids", ids)
+	if err := d.Set("clusters", result); err != nil {
+		return err
+	}
+	d.SetId(strings.Join(accountIds, "-"))
+	return nil
+}
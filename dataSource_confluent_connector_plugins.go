@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+)
+
+func dataSourceConfluentConnectorPlugins() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConnectorPluginsRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Account ID. If not set, using default account",
+			},
+			"cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"include_config_definitions": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also load the configuration definitions of every plugin (one request per plugin)",
+			},
+			"plugins": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"class": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"config_definitions": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"required": &schema.Schema{
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"default_value": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"importance": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"group": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"documentation": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// configDefaultValue renders a default value as a string, defaults are not always strings in the API
+func configDefaultValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	bytesRepresentation, _ := json.Marshal(value)
+	return string(bytesRepresentation)
+}
+
+func dataSourceConnectorPluginsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	log.Print("Reading connector plugins for account " + accountId.(string) + " and cluster " + clusterId)
+
+	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	plugins, err := config.getConnectorPlugins(*cluster)
+	if err != nil {
+		return err
+	}
+
+	var result []map[string]interface{}
+	for _, plugin := range plugins {
+		var definitions []map[string]interface{}
+		if d.Get("include_config_definitions").(bool) {
+			validation, err := config.validateConnectorConfig(*cluster, plugin.Class, map[string]string{"connector.class": plugin.Class})
+			if err != nil {
+				return err
+			}
+			for _, c := range validation.Configs {
+				definitions = append(definitions, map[string]interface{}{
+					"name":          c.Definition.Name,
+					"type":          c.Definition.Type,
+					"required":      c.Definition.Required,
+					"default_value": configDefaultValue(c.Definition.DefaultValue),
+					"importance":    c.Definition.Importance,
+					"group":         c.Definition.Group,
+					"documentation": c.Definition.Documentation,
+				})
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"class":              plugin.Class,
+			"type":               plugin.Type,
+			"version":            plugin.Version,
+			"config_definitions": definitions,
+		})
+	}
+
+	if err := d.Set("plugins", result); err != nil {
+		return err
+	}
+	d.SetId(accountId.(string) + "-" + clusterId)
+	return nil
+}
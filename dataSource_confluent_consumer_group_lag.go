@@ -0,0 +1,113 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"sort"
+)
+
+func dataSourceConfluentConsumerGroupLag() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConsumerGroupLagRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Account ID. If not set, using default account",
+			},
+			"cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"topic": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"total_lag": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"partitions": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"committed_offset": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"log_end_offset": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"lag": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"consumer_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConsumerGroupLagRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	groupId := d.Get("group_id").(string)
+	topic := d.Get("topic").(string)
+	log.Print("Reading lag of consumer group " + groupId + " on topic " + topic + " in cluster " + clusterId)
+
+	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	lags, err := config.getConsumerLags(*cluster, groupId)
+	if err != nil {
+		return err
+	}
+	sort.Slice(lags, func(i, j int) bool { return lags[i].PartitionId < lags[j].PartitionId })
+
+	var totalLag int64
+	var partitions []map[string]interface{}
+	for _, lag := range lags {
+		if lag.TopicName != topic {
+			continue
+		}
+		totalLag += lag.Lag
+		partitions = append(partitions, map[string]interface{}{
+			"partition":        lag.PartitionId,
+			"committed_offset": int(lag.CurrentOffset),
+			"log_end_offset":   int(lag.LogEndOffset),
+			"lag":              int(lag.Lag),
+			"consumer_id":      lag.ConsumerId,
+		})
+	}
+
+	d.Set("total_lag", int(totalLag))
+	if err := d.Set("partitions", partitions); err != nil {
+		return err
+	}
+	d.SetId(accountId.(string) + "-" + clusterId + "-" + groupId + "-" + topic)
+	return nil
+}
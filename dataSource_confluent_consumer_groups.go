@@ -0,0 +1,119 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+)
+
+func dataSourceConfluentConsumerGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConsumerGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Account ID. If not set, using default account",
+			},
+			"cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"consumer_groups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_simple": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"partition_assignor": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"members": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"consumer_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"client_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"instance_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConsumerGroupsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	log.Print("Reading consumer groups for account " + accountId.(string) + " and cluster " + clusterId)
+
+	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	consumerGroups, err := config.getConsumerGroups(*cluster)
+	if err != nil {
+		return err
+	}
+
+	var result []map[string]interface{}
+	for _, consumerGroup := range consumerGroups {
+		consumers, err := config.getConsumers(*cluster, consumerGroup.ConsumerGroupId)
+		if err != nil {
+			return err
+		}
+		var members []map[string]interface{}
+		for _, consumer := range consumers {
+			members = append(members, map[string]interface{}{
+				"consumer_id": consumer.ConsumerId,
+				"client_id":   consumer.ClientId,
+				"instance_id": consumer.InstanceId,
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"id":                 consumerGroup.ConsumerGroupId,
+			"state":              consumerGroup.State,
+			"is_simple":          consumerGroup.IsSimple,
+			"partition_assignor": consumerGroup.PartitionAssignor,
+			"members":            members,
+		})
+	}
+
+	if err := d.Set("consumer_groups", result); err != nil {
+		return err
+	}
+	d.SetId(accountId.(string) + "-" + clusterId)
+	return nil
+}
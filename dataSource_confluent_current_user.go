@@ -0,0 +1,99 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"strconv"
+)
+
+func dataSourceConfluentCurrentUser() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCurrentUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"first_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"organization_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Default account of the user",
+			},
+			"service_account": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"service_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"service_description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deactivated": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"verified": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"internal": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"modified": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password_changed": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCurrentUserRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	user := config.Me.User
+	log.Print("Reading current user: " + user.Email)
+
+	d.SetId(strconv.Itoa(user.Id))
+	d.Set("email", user.Email)
+	d.Set("first_name", user.FirstName)
+	d.Set("last_name", user.LastName)
+	d.Set("organization_id", strconv.Itoa(user.OrganizationId))
+	d.Set("account_id", config.Me.Account.Id)
+	d.Set("service_account", user.ServiceAccount)
+	d.Set("service_name", user.ServiceName)
+	d.Set("service_description", user.ServiceDescription)
+	d.Set("deactivated", user.Deactivated)
+	d.Set("verified", user.Verified)
+	d.Set("internal", user.Internal)
+	d.Set("created", user.Created)
+	d.Set("modified", user.Modified)
+	d.Set("password_changed", user.PasswordChanged)
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"regexp"
+	"strconv"
+)
+
+func dataSourceConfluentEnvironments() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEnvironmentsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegex,
+				Description:  "Only list environments whose name matches this regular expression",
+			},
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"environments": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deactivated": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"internal": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"created": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"modified": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEnvironmentsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	nameRegex := regexp.MustCompile(d.Get("name_regex").(string))
+	log.Print("Reading environments matching: " + nameRegex.String())
+
+	ids := []string{}
+	var environments []map[string]interface{}
+	for _, account := range config.Me.Accounts {
+		if !nameRegex.MatchString(account.Name) {
+			continue
+		}
+		ids = append(ids, account.Id)
+		environments = append(environments, map[string]interface{}{
+			"id":              account.Id,
+			"name":            account.Name,
+			"organization_id": strconv.Itoa(account.OrganizationId),
+			"deactivated":     account.Deactivated,
+			"internal":        account.Internal,
+			"created":         account.Created,
+			"modified":        account.Modified,
+		})
+	}
+
+	d.Set("ids", ids)
+	if err := d.Set("environments", environments); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(config.Me.Organization.Id))
+	return nil
+}
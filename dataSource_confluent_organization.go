@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"strconv"
+)
+
+func dataSourceConfluentOrganization() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceOrganizationRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deactivated": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"billing_email": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"has_entitlement": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"plan": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Billing plan, JSON encoded",
+			},
+			"sso": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SSO settings, JSON encoded",
+			},
+			"saml": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SAML settings, JSON encoded",
+			},
+			"marketplace": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Cloud marketplace subscription, JSON encoded",
+			},
+			"audit_log_account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"audit_log_cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"audit_log_topic_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"audit_log_service_account_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"modified": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// jsonString encodes the free-form parts of the API responses, empty when there is nothing to encode
+func jsonString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	bytesRepresentation, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(bytesRepresentation)
+}
+
+func dataSourceOrganizationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	organization := config.Me.Organization
+	log.Print("Reading organization: " + organization.Name)
+
+	d.SetId(strconv.Itoa(organization.Id))
+	d.Set("name", organization.Name)
+	d.Set("resource_id", organization.ResourceId)
+	d.Set("deactivated", organization.Deactivated)
+	d.Set("billing_email", organization.BillingEmail)
+	d.Set("has_entitlement", organization.HasEntitlement)
+	d.Set("plan", jsonString(organization.Plan))
+	d.Set("sso", jsonString(organization.Sso))
+	d.Set("saml", jsonString(organization.Saml))
+	d.Set("marketplace", jsonString(organization.Marketplace))
+	d.Set("audit_log_account_id", organization.AuditLog.AccountId)
+	d.Set("audit_log_cluster_id", organization.AuditLog.ClusterId)
+	d.Set("audit_log_topic_name", organization.AuditLog.TopicName)
+	d.Set("audit_log_service_account_id", organization.AuditLog.ServiceAccountId)
+	d.Set("created", organization.Created)
+	d.Set("modified", organization.Modified)
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"strconv"
+)
+
+func dataSourceConfluentSchema() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSchemaRead,
+
+		Schema: withSchemaRegistryCredentials(map[string]*schema.Schema{
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "latest",
+				Description: "Version to read, latest by default",
+			},
+			"schema_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"version_number": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"format": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fingerprint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"reference": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subject": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceSchemaRead(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	subject := d.Get("subject").(string)
+	version := d.Get("version").(string)
+	log.Print("Reading version " + version + " of subject " + subject)
+
+	registered, err := client.getSchema(subject, version)
+	if err != nil {
+		return err
+	}
+	if registered == nil {
+		return errors.New("Unable to find version " + version + " of subject " + subject)
+	}
+
+	d.Set("schema_id", registered.Id)
+	d.Set("version_number", registered.Version)
+	d.Set("format", registered.Format())
+	d.Set("schema", registered.Schema)
+	d.Set("fingerprint", schemaFingerprint(registered.Schema))
+	if err := d.Set("reference", flattenSchemaReferences(registered.References)); err != nil {
+		return err
+	}
+	d.SetId(subject + "/" + strconv.Itoa(registered.Version))
+	return nil
+}
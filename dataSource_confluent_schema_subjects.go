@@ -0,0 +1,50 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"strings"
+)
+
+func dataSourceConfluentSchemaSubjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSchemaSubjectsRead,
+
+		Schema: withSchemaRegistryCredentials(map[string]*schema.Schema{
+			"prefix": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list subjects starting with this prefix",
+			},
+			"subjects": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		}),
+	}
+}
+
+func dataSourceSchemaSubjectsRead(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	prefix := d.Get("prefix").(string)
+	log.Print("Reading subjects starting with: " + prefix)
+
+	subjects, err := client.getSubjects()
+	if err != nil {
+		return err
+	}
+	result := []string{}
+	for _, subject := range subjects {
+		if strings.HasPrefix(subject, prefix) {
+			result = append(result, subject)
+		}
+	}
+
+	d.Set("subjects", result)
+	d.SetId(client.Endpoint + "/" + prefix)
+	return nil
+}
@@ -19,15 +19,69 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("CONFLUENT_PASSWORD", nil),
 				Description: "Confluent password",
 			},
+			"schema_registry_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CONFLUENT_SCHEMA_REGISTRY_ENDPOINT", ""),
+				Description: "Default Schema Registry endpoint for schema resources",
+			},
+			"schema_registry_api_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CONFLUENT_SCHEMA_REGISTRY_API_KEY", ""),
+				Description: "Default Schema Registry API key for schema resources",
+			},
+			"schema_registry_api_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CONFLUENT_SCHEMA_REGISTRY_API_SECRET", ""),
+				Description: "Default Schema Registry API secret for schema resources",
+			},
+			"cloud_api_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CONFLUENT_CLOUD_API_KEY", ""),
+				Description: "Cloud API key for the public Confluent Cloud API, used by client quotas",
+			},
+			"cloud_api_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CONFLUENT_CLOUD_API_SECRET", ""),
+				Description: "Cloud API secret for the public Confluent Cloud API, used by client quotas",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"confluent_cluster": resourceCluster(),
 			"confluent_topic":   resourceTopic(),
 			"confluent_api_key":   resourceApiKey(),
+			"confluent_environment": resourceEnvironment(),
+			"confluent_schema_registry": resourceSchemaRegistry(),
+			"confluent_schema": resourceSchema(),
+			"confluent_schema_registry_config": resourceSchemaRegistryConfig(),
+			"confluent_subject_config": resourceSubjectConfig(),
+			"confluent_connector": resourceConnector(),
+			"confluent_ksql_cluster": resourceKsqlCluster(),
+			"confluent_ksql_statement": resourceKsqlStatement(),
+			"confluent_consumer_group_offsets": resourceConsumerGroupOffsets(),
+			"confluent_kafka_client_quota": resourceKafkaClientQuota(),
+			"confluent_cluster_link": resourceClusterLink(),
+			"confluent_mirror_topic": resourceMirrorTopic(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"confluent_cluster": dataSourceConfluentCluster(),
+			"confluent_clusters": dataSourceConfluentClusters(),
+			"confluent_schema": dataSourceConfluentSchema(),
+			"confluent_schema_subjects": dataSourceConfluentSchemaSubjects(),
+			"confluent_connector_plugins": dataSourceConfluentConnectorPlugins(),
+			"confluent_consumer_groups": dataSourceConfluentConsumerGroups(),
+			"confluent_consumer_group_lag": dataSourceConfluentConsumerGroupLag(),
 			"confluent_account": dataSourceConfluentAccount(),
+			"confluent_api_keys": dataSourceConfluentApiKeys(),
+			"confluent_environments": dataSourceConfluentEnvironments(),
+			"confluent_organization": dataSourceConfluentOrganization(),
+			"confluent_current_user": dataSourceConfluentCurrentUser(),
 		},
 		ConfigureFunc: configureProvider,
 	}
@@ -38,6 +92,14 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		ApiEndpoint: "https://confluent.cloud",
 		Email:       d.Get("email").(string),
 		Password:    d.Get("password").(string),
+
+		SchemaRegistryEndpoint:  d.Get("schema_registry_endpoint").(string),
+		SchemaRegistryApiKey:    d.Get("schema_registry_api_key").(string),
+		SchemaRegistryApiSecret: d.Get("schema_registry_api_secret").(string),
+
+		CloudApiEndpoint: "https://api.confluent.cloud",
+		CloudApiKey:      d.Get("cloud_api_key").(string),
+		CloudApiSecret:   d.Get("cloud_api_secret").(string),
 	}
 
 	return &config, nil
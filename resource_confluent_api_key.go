@@ -1,16 +1,31 @@
 package main
 
 import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func resourceApiKey() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceApiKeyCreate,
 		Read:   resourceApiKeyRead,
-		//Update: resourceApiKeyUpdate,
+		Update: resourceApiKeyUpdate,
 		Delete: resourceApiKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceApiKeyImport,
+		},
+
+		CustomizeDiff: resourceApiKeyCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"account_id": &schema.Schema{
@@ -20,17 +35,65 @@ func resourceApiKey() *schema.Resource {
 				Optional: true,
 			},
 			"cluster_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"resource_ids"},
+				Description:   "Kafka cluster the key is scoped to. Shortcut for a single entry in resource_ids",
+			},
+			"resource_ids": &schema.Schema{
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				ConflictsWith: []string{"cluster_id"},
+				Description:   "Kafka and Schema Registry clusters the key is scoped to. If empty along with cluster_id, a Cloud key is created",
+			},
+			"rotation_period": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  "Rotate the key once it is older than this duration (eg: 2160h). If not set, the key is never rotated",
+			},
+			"overlap_period": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "24h",
+				ValidateFunc: validateDuration,
+				Description:  "How long the previous key stays active after a rotation",
+			},
+			"keepers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that trigger a rotation when changed",
+			},
+			"active": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Set to false to deactivate the key without deleting it",
+			},
+			"wait_for_propagation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until the cluster accepts the new key before returning",
 			},
 			"key": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"secret": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"rotation_period", "keepers"},
+				Description:   "Secret of an imported key. It cannot be read back from Confluent Cloud, and it must not be set for new or rotated keys",
 			},
 			"created": &schema.Schema{
 				Type:     schema.TypeString,
@@ -40,10 +103,154 @@ func resourceApiKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"sasl_jaas_config": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"client_properties": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Kafka client configuration in Java properties format",
+			},
+			"librdkafka_config": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Kafka client configuration for librdkafka based clients",
+			},
+			"rotated_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"previous_key_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"previous_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"previous_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"previous_expires_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+func validateDuration(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errs = append(errs, errors.New(k+": "+err.Error()))
+	}
+	return
+}
+
+// apiKeyRotationDue returns true when the current key is older than the rotation period
+func apiKeyRotationDue(rotatedAt string, rotationPeriod string) bool {
+	if rotatedAt == "" || rotationPeriod == "" {
+		return false
+	}
+	rotated, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false
+	}
+	period, err := time.ParseDuration(rotationPeriod)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(rotated.Add(period))
+}
+
+// apiKeyOverlapExpired returns true when the previous key has outlived its overlap period
+func apiKeyOverlapExpired(previousExpiresAt string) bool {
+	if previousExpiresAt == "" {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339, previousExpiresAt)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(expires)
+}
+
+func resourceApiKeyCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		if _, secretSet := d.GetOk("secret"); secretSet {
+			return errors.New("secret can only be set on imported API keys, it is generated for new ones")
+		}
+		return nil
+	}
+	if d.HasChange("keepers") || apiKeyRotationDue(d.Get("rotated_at").(string), d.Get("rotation_period").(string)) {
+		for _, key := range []string{"key", "secret", "created", "modified", "rotated_at", "previous_key_id", "previous_key", "previous_secret", "previous_expires_at", "sasl_jaas_config", "client_properties", "librdkafka_config"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if d.HasChange("secret") {
+		for _, key := range []string{"sasl_jaas_config", "client_properties", "librdkafka_config"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	if apiKeyOverlapExpired(d.Get("previous_expires_at").(string)) {
+		for _, key := range []string{"previous_key_id", "previous_key", "previous_secret", "previous_expires_at"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// apiKeyResourceIds returns the resources the key is scoped to, from resource_ids or cluster_id
+func apiKeyResourceIds(d *schema.ResourceData) []string {
+	resourceIds := []string{}
+	if v, ok := d.GetOk("resource_ids"); ok {
+		for _, resourceId := range v.(*schema.Set).List() {
+			resourceIds = append(resourceIds, resourceId.(string))
+		}
+		return resourceIds
+	}
+	if clusterId, ok := d.GetOk("cluster_id"); ok {
+		resourceIds = append(resourceIds, clusterId.(string))
+	}
+	return resourceIds
+}
+
+func firstResourceId(resourceIds []string) string {
+	if len(resourceIds) == 0 {
+		return ""
+	}
+	return resourceIds[0]
+}
+
+// apiKeyKafkaCluster returns the first Kafka cluster the key is scoped to, nil if there is none
+func apiKeyKafkaCluster(config *Config, accountId string, resourceIds []string) (*Cluster, error) {
+	clusters, err := config.getClustersPerAccount(accountId)
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceId := range resourceIds {
+		for _, cluster := range clusters.Clusters {
+			if cluster.Id == resourceId {
+				return &cluster, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 func resourceApiKeyCreate(d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	if err := config.connect(); err != nil {
@@ -54,20 +261,50 @@ func resourceApiKeyCreate(d *schema.ResourceData, m interface{}) error {
 	if ! accountIdSet {
 		accountId = config.Me.Account.Id
 	}
-	clusterId := d.Get("cluster_id").(string)
+	resourceIds := apiKeyResourceIds(d)
 
-	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	apiKey, err := config.createApiKey(accountId.(string), resourceIds)
 	if err != nil {
 		return err
 	}
+	d.SetId(strconv.Itoa(apiKey.Id))
+	d.Set("account_id", accountId.(string))
+	d.Set("secret", apiKey.Secret)
+	d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+
+	if !d.Get("active").(bool) {
+		if err := config.setApiKeyDeactivated(accountId.(string), resourceIds, apiKey.Id, true); err != nil {
+			return err
+		}
+	} else if d.Get("wait_for_propagation").(bool) {
+		if err := waitForApiKeyPropagation(config, accountId.(string), resourceIds, *apiKey, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+	return resourceApiKeyRead(d, m)
+}
 
-	apiKey, err := config.createApiKey(*cluster)
+// waitForApiKeyPropagation polls the cluster REST endpoint with the new credentials until they are accepted
+func waitForApiKeyPropagation(config *Config, accountId string, resourceIds []string, apiKey ApiKey, timeout time.Duration) error {
+	cluster, err := apiKeyKafkaCluster(config, accountId, resourceIds)
 	if err != nil {
 		return err
 	}
-	d.SetId(strconv.Itoa(apiKey.Id))
-	d.Set("secret", apiKey.Secret)
-	return resourceApiKeyRead(d, m)
+	if cluster == nil {
+		log.Print("API Key " + apiKey.Key + " is not scoped to a Kafka cluster, not waiting for propagation")
+		return nil
+	}
+	log.Print("Waiting for API Key " + apiKey.Key + " to be usable on cluster " + cluster.Id)
+	return resource.Retry(timeout, func() *resource.RetryError {
+		accepted, err := config.checkApiKey(*cluster, apiKey.Key, apiKey.Secret)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if !accepted {
+			return resource.RetryableError(errors.New("API Key " + apiKey.Key + " not yet accepted by cluster " + cluster.Id))
+		}
+		return nil
+	})
 }
 
 func resourceApiKeyRead(d *schema.ResourceData, m interface{}) error {
@@ -82,13 +319,9 @@ func resourceApiKeyRead(d *schema.ResourceData, m interface{}) error {
 	}
 	keyId := d.Id()
 	keyIdInt, _ := strconv.Atoi(keyId)
-	clusterId := d.Get("cluster_id").(string)
+	resourceIds := apiKeyResourceIds(d)
 
-	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
-	if err != nil {
-		return err
-	}
-	apiKey, err := config.getApiKey(*cluster, keyIdInt)
+	apiKey, err := config.getApiKey(accountId.(string), firstResourceId(resourceIds), keyIdInt)
 	if err != nil {
 		return err
 	}
@@ -97,14 +330,269 @@ func resourceApiKeyRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
+	d.Set("account_id", accountId.(string))
 	d.Set("key", apiKey.Key)
-	//d.Set("secret", apiKey.Secret) //FIXME not sure
+	// The secret is never returned once the key is created, keep the one already in state
 	d.Set("created", apiKey.Created)
 	d.Set("modified", apiKey.Modified)
+	d.Set("active", !apiKey.Deactivated)
+	d.Set("resource_ids", apiKey.ResourceIds())
+	// The API does not keep the resource IDs in order, only pick one when the current cluster_id is not among them
+	if clusterId := d.Get("cluster_id").(string); clusterId == "" || !containsString(apiKey.ResourceIds(), clusterId) {
+		d.Set("cluster_id", firstResourceId(apiKey.ResourceIds()))
+	}
 
+	cluster, err := apiKeyKafkaCluster(config, accountId.(string), apiKey.ResourceIds())
+	if err != nil {
+		return err
+	}
+	// Without the secret (eg: imported key) the configs could not authenticate, leave them unset
+	if secret := d.Get("secret").(string); cluster != nil && secret != "" {
+		sasl := saslConfig(*apiKey, secret)
+		d.Set("sasl_jaas_config", sasl.JaasConfig())
+		d.Set("client_properties", sasl.ClientProperties(*cluster))
+		d.Set("librdkafka_config", sasl.LibrdkafkaConfig(*cluster))
+	} else {
+		d.Set("sasl_jaas_config", "")
+		d.Set("client_properties", "")
+		d.Set("librdkafka_config", map[string]string{})
+	}
+
+	if previousKeyId := d.Get("previous_key_id").(string); previousKeyId != "" {
+		previousKeyIdInt, _ := strconv.Atoi(previousKeyId)
+		previousApiKey, err := config.getApiKey(accountId.(string), firstResourceId(resourceIds), previousKeyIdInt)
+		if err != nil {
+			return err
+		}
+		if previousApiKey == nil {
+			log.Print("Previous API Key " + previousKeyId + " no longer exists")
+			clearPreviousApiKey(d)
+		}
+	}
+
+	return nil
+}
+
+// resourceApiKeyImport accepts either <cluster_id>/<key_id> or the public key string
+func resourceApiKeyImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return nil, err
+	}
+
+	importId := d.Id()
+	clusterId := ""
+	keyId := 0
+	if tokens := strings.Split(importId, "/"); len(tokens) == 2 {
+		clusterId = tokens[0]
+		keyIdInt, err := strconv.Atoi(tokens[1])
+		if err != nil {
+			return nil, errors.New("Invalid API Key ID in " + importId + ", expecting <cluster_id>/<key_id>")
+		}
+		keyId = keyIdInt
+	}
+
+	for _, account := range config.Me.Accounts {
+		apiKeys, err := config.getApiKeys(account.Id, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, apiKey := range apiKeys {
+			if (keyId != 0 && apiKey.Id == keyId && containsString(apiKey.ResourceIds(), clusterId)) || apiKey.Key == importId {
+				log.Print("Importing API Key " + apiKey.Key + " from account " + account.Id)
+				d.SetId(strconv.Itoa(apiKey.Id))
+				d.Set("account_id", account.Id)
+				d.Set("resource_ids", apiKey.ResourceIds())
+				if clusterId == "" {
+					clusterId = firstResourceId(apiKey.ResourceIds())
+				}
+				d.Set("cluster_id", clusterId)
+				d.Set("overlap_period", "24h")
+				d.Set("wait_for_propagation", false)
+				d.Set("active", !apiKey.Deactivated)
+				// The rotation period starts at import, an old key is not rotated on the first apply
+				d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+				return []*schema.ResourceData{d}, nil
+			}
+		}
+	}
+	return nil, errors.New("Unable to find API Key " + importId)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type SaslConfig struct {
+	Mechanism string
+	Username  string
+	Password  string
+}
+
+func saslConfig(apiKey ApiKey, secret string) SaslConfig {
+	mechanism := apiKey.SaslMechanism
+	if mechanism == "" {
+		mechanism = "PLAIN"
+	}
+	return SaslConfig{
+		Mechanism: mechanism,
+		Username:  apiKey.Key,
+		Password:  secret,
+	}
+}
+
+func (sasl *SaslConfig) JaasConfig() string {
+	loginModule := "org.apache.kafka.common.security.plain.PlainLoginModule"
+	if strings.HasPrefix(sasl.Mechanism, "SCRAM") {
+		loginModule = "org.apache.kafka.common.security.scram.ScramLoginModule"
+	}
+	return loginModule + " required username=\"" + sasl.Username + "\" password=\"" + sasl.Password + "\";"
+}
+
+func (sasl *SaslConfig) ClientProperties(cluster Cluster) string {
+	return "bootstrap.servers=" + cluster.Host() + ":" + strconv.Itoa(cluster.Port()) + "\n" +
+		"security.protocol=SASL_SSL\n" +
+		"sasl.mechanism=" + sasl.Mechanism + "\n" +
+		"sasl.jaas.config=" + sasl.JaasConfig() + "\n"
+}
+
+func (sasl *SaslConfig) LibrdkafkaConfig(cluster Cluster) map[string]string {
+	return map[string]string{
+		"bootstrap.servers": cluster.Host() + ":" + strconv.Itoa(cluster.Port()),
+		"security.protocol": "SASL_SSL",
+		"sasl.mechanisms":   sasl.Mechanism,
+		"sasl.username":     sasl.Username,
+		"sasl.password":     sasl.Password,
+	}
+}
+
+func resourceApiKeyUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if ! accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	resourceIds := apiKeyResourceIds(d)
+
+	if d.HasChange("active") {
+		keyIdInt, _ := strconv.Atoi(d.Id())
+		active := d.Get("active").(bool)
+		log.Print("Setting API Key " + d.Id() + " active: " + strconv.FormatBool(active))
+		if err := config.setApiKeyDeactivated(accountId.(string), resourceIds, keyIdInt, !active); err != nil {
+			return err
+		}
+	}
+
+	prior := priorApiKeyState(d)
+	if d.HasChange("keepers") || apiKeyRotationDue(prior.RotatedAt, d.Get("rotation_period").(string)) {
+		if err := rotateApiKey(config, d, accountId.(string), resourceIds, prior); err != nil {
+			return err
+		}
+	} else if apiKeyOverlapExpired(prior.PreviousExpiresAt) {
+		if err := deletePreviousApiKey(config, d, accountId.(string), resourceIds, prior.PreviousKeyId); err != nil {
+			return err
+		}
+	}
+	return resourceApiKeyRead(d, m)
+}
+
+// apiKeyState holds the key attributes as they were before the plan, the diff marks them as computed when a rotation is due
+type apiKeyState struct {
+	Key               string
+	Secret            string
+	RotatedAt         string
+	PreviousKeyId     string
+	PreviousExpiresAt string
+}
+
+func priorApiKeyState(d *schema.ResourceData) apiKeyState {
+	prior := func(key string) string {
+		old, _ := d.GetChange(key)
+		return old.(string)
+	}
+	return apiKeyState{
+		Key:               prior("key"),
+		Secret:            prior("secret"),
+		RotatedAt:         prior("rotated_at"),
+		PreviousKeyId:     prior("previous_key_id"),
+		PreviousExpiresAt: prior("previous_expires_at"),
+	}
+}
+
+// rotateApiKey creates a new key and keeps the current one as the previous key for the overlap period
+func rotateApiKey(config *Config, d *schema.ResourceData, accountId string, resourceIds []string, prior apiKeyState) error {
+	if err := deletePreviousApiKey(config, d, accountId, resourceIds, prior.PreviousKeyId); err != nil {
+		return err
+	}
+
+	log.Print("Rotating API Key " + d.Id())
+	apiKey, err := config.createApiKey(accountId, resourceIds)
+	if err != nil {
+		return err
+	}
+	overlap, _ := time.ParseDuration(d.Get("overlap_period").(string))
+	now := time.Now().UTC()
+
+	previousKeyId := d.Id()
+	d.Set("previous_key_id", previousKeyId)
+	d.Set("previous_key", prior.Key)
+	d.Set("previous_secret", prior.Secret)
+	d.Set("previous_expires_at", now.Add(overlap).Format(time.RFC3339))
+	d.SetId(strconv.Itoa(apiKey.Id))
+	d.Set("secret", apiKey.Secret)
+	d.Set("rotated_at", now.Format(time.RFC3339))
+
+	if !d.Get("active").(bool) {
+		if err := config.setApiKeyDeactivated(accountId, resourceIds, apiKey.Id, true); err != nil {
+			return err
+		}
+	} else if d.Get("wait_for_propagation").(bool) {
+		if err := waitForApiKeyPropagation(config, accountId, resourceIds, *apiKey, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	if overlap == 0 {
+		return deletePreviousApiKey(config, d, accountId, resourceIds, previousKeyId)
+	}
+	return nil
+}
+
+func deletePreviousApiKey(config *Config, d *schema.ResourceData, accountId string, resourceIds []string, previousKeyId string) error {
+	if previousKeyId == "" {
+		return nil
+	}
+	log.Print("Deleting previous API Key " + previousKeyId)
+	previousKeyIdInt, _ := strconv.Atoi(previousKeyId)
+	previousApiKey, err := config.getApiKey(accountId, firstResourceId(resourceIds), previousKeyIdInt)
+	if err != nil {
+		return err
+	}
+	if previousApiKey != nil {
+		if err := config.deleteApiKey(accountId, resourceIds, previousKeyIdInt); err != nil {
+			return err
+		}
+	}
+	clearPreviousApiKey(d)
 	return nil
 }
 
+func clearPreviousApiKey(d *schema.ResourceData) {
+	d.Set("previous_key_id", "")
+	d.Set("previous_key", "")
+	d.Set("previous_secret", "")
+	d.Set("previous_expires_at", "")
+}
+
 func resourceApiKeyDelete(d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	if err := config.connect(); err != nil {
@@ -117,12 +605,11 @@ func resourceApiKeyDelete(d *schema.ResourceData, m interface{}) error {
 	}
 	keyId := d.Id()
 	keyIdInt, _ := strconv.Atoi(keyId)
-	clusterId := d.Get("cluster_id").(string)
-	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
-	if err != nil {
+	resourceIds := apiKeyResourceIds(d)
+	if err := deletePreviousApiKey(config, d, accountId.(string), resourceIds, d.Get("previous_key_id").(string)); err != nil {
 		return err
 	}
-	errDeleteApiKey := config.deleteApiKey(*cluster, keyIdInt)
+	errDeleteApiKey := config.deleteApiKey(accountId.(string), resourceIds, keyIdInt)
 	if errDeleteApiKey != nil {
 		return errDeleteApiKey
 	}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestApiKeyRotationDue(t *testing.T) {
+	now := time.Now().UTC()
+	cases := []struct {
+		rotatedAt      string
+		rotationPeriod string
+		due            bool
+	}{
+		{"", "24h", false},
+		{now.Format(time.RFC3339), "", false},
+		{now.Format(time.RFC3339), "24h", false},
+		{now.Add(-25 * time.Hour).Format(time.RFC3339), "24h", true},
+		{"not a date", "24h", false},
+		{now.Add(-25 * time.Hour).Format(time.RFC3339), "not a duration", false},
+	}
+	for _, c := range cases {
+		if due := apiKeyRotationDue(c.rotatedAt, c.rotationPeriod); due != c.due {
+			t.Errorf("apiKeyRotationDue(%q, %q) = %v, expected %v", c.rotatedAt, c.rotationPeriod, due, c.due)
+		}
+	}
+}
+
+func TestApiKeyOverlapExpired(t *testing.T) {
+	now := time.Now().UTC()
+	cases := []struct {
+		previousExpiresAt string
+		expired           bool
+	}{
+		{"", false},
+		{now.Add(time.Hour).Format(time.RFC3339), false},
+		{now.Add(-time.Hour).Format(time.RFC3339), true},
+		{"not a date", false},
+	}
+	for _, c := range cases {
+		if expired := apiKeyOverlapExpired(c.previousExpiresAt); expired != c.expired {
+			t.Errorf("apiKeyOverlapExpired(%q) = %v, expected %v", c.previousExpiresAt, expired, c.expired)
+		}
+	}
+}
+
+// apiKeysStandIn serves the api_keys endpoints from an in-memory list of keys
+type apiKeysStandIn struct {
+	mutex   sync.Mutex
+	keys    map[int]ApiKey
+	nextId  int
+	deleted []int
+}
+
+func (s *apiKeysStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/api/api_keys":
+		var keys []ApiKey
+		for _, key := range s.keys {
+			keys = append(keys, key)
+		}
+		json.NewEncoder(w).Encode(GetApiKeysResponse{ApiKeys: keys})
+	case r.Method == "POST" && r.URL.Path == "/api/api_keys":
+		key := ApiKey{Id: s.nextId, Key: "KEY" + strconv.Itoa(s.nextId), Secret: "secret" + strconv.Itoa(s.nextId)}
+		s.keys[key.Id] = key
+		s.nextId++
+		json.NewEncoder(w).Encode(CreateApiKeyResponse{ApiKey: key})
+	case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/api_keys/"):
+		id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/api_keys/"))
+		delete(s.keys, id)
+		s.deleted = append(s.deleted, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testApiKeyRotation(t *testing.T, overlapPeriod string) (*apiKeysStandIn, *schema.ResourceData) {
+	standIn := &apiKeysStandIn{
+		keys: map[int]ApiKey{
+			1: {Id: 1, Key: "KEY1"},
+			2: {Id: 2, Key: "KEY2"},
+		},
+		nextId: 3,
+	}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	config := &Config{
+		ApiEndpoint: server.URL,
+		Session:     &Session{Token: "session"},
+		AccessToken: &AccessToken{Token: "token"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceApiKey().Schema, map[string]interface{}{
+		"account_id":     "env-1",
+		"resource_ids":   []interface{}{"lsrc-1"},
+		"overlap_period": overlapPeriod,
+	})
+	d.SetId("2")
+	prior := apiKeyState{
+		Key:               "KEY2",
+		Secret:            "secret2",
+		RotatedAt:         time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339),
+		PreviousKeyId:     "1",
+		PreviousExpiresAt: time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339),
+	}
+	if err := rotateApiKey(config, d, "env-1", []string{"lsrc-1"}, prior); err != nil {
+		t.Fatal(err)
+	}
+	return standIn, d
+}
+
+func TestRotateApiKey(t *testing.T) {
+	standIn, d := testApiKeyRotation(t, "24h")
+
+	if len(standIn.deleted) != 1 || standIn.deleted[0] != 1 {
+		t.Errorf("expected the older previous key 1 to be deleted, deleted %v", standIn.deleted)
+	}
+	if d.Id() != "3" {
+		t.Errorf("expected the new key 3 to be current, got %s", d.Id())
+	}
+	expected := map[string]string{
+		"secret":          "secret3",
+		"previous_key_id": "2",
+		"previous_key":    "KEY2",
+		"previous_secret": "secret2",
+	}
+	for key, value := range expected {
+		if d.Get(key).(string) != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, d.Get(key).(string))
+		}
+	}
+	if d.Get("previous_expires_at").(string) == "" || d.Get("rotated_at").(string) == "" {
+		t.Errorf("expected previous_expires_at and rotated_at to be set")
+	}
+}
+
+func TestRotateApiKeyWithoutOverlap(t *testing.T) {
+	standIn, d := testApiKeyRotation(t, "0s")
+
+	if len(standIn.deleted) != 2 || standIn.deleted[0] != 1 || standIn.deleted[1] != 2 {
+		t.Errorf("expected keys 1 and 2 to be deleted, deleted %v", standIn.deleted)
+	}
+	if _, exists := standIn.keys[3]; !exists || len(standIn.keys) != 1 {
+		t.Errorf("expected only the new key 3 to remain, got %v", standIn.keys)
+	}
+	if d.Get("previous_key_id").(string) != "" || d.Get("previous_secret").(string) != "" {
+		t.Errorf("expected the previous key to be cleared")
+	}
+}
+
+func TestSaslConfig(t *testing.T) {
+	cluster := Cluster{Endpoint: "SASL_SSL://pkc-abc.eu-west-1.aws.confluent.cloud:9092"}
+
+	plain := saslConfig(ApiKey{Key: "KEY"}, "SECRET")
+	expected := "bootstrap.servers=pkc-abc.eu-west-1.aws.confluent.cloud:9092\n" +
+		"security.protocol=SASL_SSL\n" +
+		"sasl.mechanism=PLAIN\n" +
+		"sasl.jaas.config=org.apache.kafka.common.security.plain.PlainLoginModule required username=\"KEY\" password=\"SECRET\";\n"
+	if properties := plain.ClientProperties(cluster); properties != expected {
+		t.Errorf("unexpected client properties:\n%s", properties)
+	}
+	if librdkafka := plain.LibrdkafkaConfig(cluster); librdkafka["sasl.username"] != "KEY" || librdkafka["sasl.mechanisms"] != "PLAIN" {
+		t.Errorf("unexpected librdkafka config: %v", librdkafka)
+	}
+
+	scram := saslConfig(ApiKey{Key: "KEY", SaslMechanism: "SCRAM-SHA-512"}, "SECRET")
+	if jaas := scram.JaasConfig(); !strings.HasPrefix(jaas, "org.apache.kafka.common.security.scram.ScramLoginModule required") {
+		t.Errorf("unexpected JAAS config for SCRAM: %s", jaas)
+	}
+}
+
+func TestCheckApiKey(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	config := &Config{}
+	cluster := Cluster{Id: "lkc-1", ApiEndpoint: server.URL}
+
+	cases := []struct {
+		status   int
+		accepted bool
+		fails    bool
+	}{
+		{http.StatusOK, true, false},
+		{http.StatusUnauthorized, false, false},
+		{http.StatusForbidden, false, false},
+		{http.StatusNotFound, false, false},
+		{http.StatusServiceUnavailable, false, false},
+		{http.StatusBadRequest, false, true},
+	}
+	for _, c := range cases {
+		status = c.status
+		accepted, err := config.checkApiKey(cluster, "KEY", "SECRET")
+		if accepted != c.accepted || (err != nil) != c.fails {
+			t.Errorf("checkApiKey with status %d = %v, %v", c.status, accepted, err)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"strconv"
+)
+
+func resourceClusterLink() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterLinkCreate,
+		Read:   resourceClusterLinkRead,
+		Update: resourceClusterLinkUpdate,
+		Delete: resourceClusterLinkDelete,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Computed:    true,
+				Optional:    true,
+				Description: "Account of the destination cluster",
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"destination_cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"destination_api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "API Key of the destination cluster, the provider credentials are used when not set",
+			},
+			"destination_api_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"source_account_id": &schema.Schema{
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "Account of the source cluster, defaults to account_id",
+			},
+			"source_cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"source_bootstrap_endpoint": &schema.Schema{
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+				Description: "host:port of the source cluster, looked up when the source cluster belongs to one of your accounts",
+			},
+			"source_api_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"source_api_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"config": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional cluster link configuration, e.g. consumer.offset.sync.enable",
+			},
+			"link_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func clusterLinkCredentials(d *schema.ResourceData) ClusterLinkCredentials {
+	return ClusterLinkCredentials{
+		ApiKey:    d.Get("destination_api_key").(string),
+		ApiSecret: d.Get("destination_api_secret").(string),
+	}
+}
+
+func clusterLinkDestination(d *schema.ResourceData, config *Config) (*Cluster, error) {
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	return config.getClusterPerAccount(accountId.(string), d.Get("destination_cluster_id").(string))
+}
+
+func clusterLinkSourceJaasConfig(d *schema.ResourceData) KafkaConfig {
+	sasl := SaslConfig{
+		Mechanism: "PLAIN",
+		Username:  d.Get("source_api_key").(string),
+		Password:  d.Get("source_api_secret").(string),
+	}
+	return KafkaConfig{Name: "sasl.jaas.config", Value: sasl.JaasConfig()}
+}
+
+func getClusterLinkConfigs(d *schema.ResourceData) []KafkaConfig {
+	var configs []KafkaConfig
+	for name, value := range d.Get("config").(map[string]interface{}) {
+		configs = append(configs, KafkaConfig{Name: name, Value: value.(string)})
+	}
+	return configs
+}
+
+func resourceClusterLinkCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	destination, err := clusterLinkDestination(d, config)
+	if err != nil {
+		return err
+	}
+	bootstrapEndpoint, bootstrapEndpointSet := d.GetOk("source_bootstrap_endpoint")
+	if !bootstrapEndpointSet {
+		sourceAccountId, sourceAccountIdSet := d.GetOk("source_account_id")
+		if !sourceAccountIdSet {
+			sourceAccountId = destination.AccountId
+		}
+		source, err := config.getClusterPerAccount(sourceAccountId.(string), d.Get("source_cluster_id").(string))
+		if err != nil {
+			return err
+		}
+		bootstrapEndpoint = source.Host() + ":" + strconv.Itoa(source.Port())
+	}
+
+	name := d.Get("name").(string)
+	link := CreateClusterLinkRequest{
+		SourceClusterId: d.Get("source_cluster_id").(string),
+		Configs: append([]KafkaConfig{
+			{Name: "bootstrap.servers", Value: bootstrapEndpoint.(string)},
+			{Name: "security.protocol", Value: "SASL_SSL"},
+			{Name: "sasl.mechanism", Value: "PLAIN"},
+			clusterLinkSourceJaasConfig(d),
+		}, getClusterLinkConfigs(d)...),
+	}
+	log.Print("Creating cluster link " + name + " from cluster " + link.SourceClusterId + " to cluster " + destination.Id)
+	if err := config.createClusterLink(*destination, clusterLinkCredentials(d), name, link); err != nil {
+		return err
+	}
+
+	d.SetId(destination.Id + "/" + name)
+	d.Set("account_id", destination.AccountId)
+	d.Set("source_bootstrap_endpoint", bootstrapEndpoint.(string))
+	return resourceClusterLinkRead(d, m)
+}
+
+func resourceClusterLinkRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	destination, err := clusterLinkDestination(d, config)
+	if err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+	link, err := config.getClusterLink(*destination, clusterLinkCredentials(d), name)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		log.Print("Unable to find cluster link " + name + " in cluster " + destination.Id)
+		d.SetId("")
+		return nil
+	}
+	d.Set("source_cluster_id", link.SourceClusterId)
+	d.Set("link_id", link.LinkId)
+
+	// Only the configuration managed here is read back, the link has many defaults
+	linkConfigs, err := config.getClusterLinkConfigs(*destination, clusterLinkCredentials(d), name)
+	if err != nil {
+		return err
+	}
+	managedConfigs := d.Get("config").(map[string]interface{})
+	configs := make(map[string]string)
+	for _, linkConfig := range linkConfigs {
+		if _, managed := managedConfigs[linkConfig.Name]; managed {
+			configs[linkConfig.Name] = linkConfig.Value
+		}
+	}
+	d.Set("config", configs)
+
+	return nil
+}
+
+func resourceClusterLinkUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	destination, err := clusterLinkDestination(d, config)
+	if err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+	credentials := clusterLinkCredentials(d)
+
+	var configs []KafkaConfig
+	if d.HasChange("source_api_key") || d.HasChange("source_api_secret") {
+		log.Print("Updating source credentials of cluster link " + name)
+		configs = append(configs, clusterLinkSourceJaasConfig(d))
+	}
+	if d.HasChange("config") {
+		configs = append(configs, getClusterLinkConfigs(d)...)
+	}
+	if len(configs) > 0 {
+		if err := config.updateClusterLinkConfigs(*destination, credentials, name, configs); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("config") {
+		oldConfigs, newConfigs := d.GetChange("config")
+		for configName := range oldConfigs.(map[string]interface{}) {
+			if _, kept := newConfigs.(map[string]interface{})[configName]; !kept {
+				log.Print("Resetting " + configName + " of cluster link " + name + " to its default")
+				if err := config.resetClusterLinkConfig(*destination, credentials, name, configName); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return resourceClusterLinkRead(d, m)
+}
+
+func resourceClusterLinkDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	destination, err := clusterLinkDestination(d, config)
+	if err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+	log.Print("Deleting cluster link " + name + " in cluster " + destination.Id)
+	if err := config.deleteClusterLink(*destination, clusterLinkCredentials(d), name); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
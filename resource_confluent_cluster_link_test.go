@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestClusterLinkSecretNotLogged(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		body.ReadFrom(r.Body)
+		received = body.String()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	config := &Config{}
+	cluster := Cluster{Id: "lkc-us", ApiEndpoint: server.URL}
+	sasl := SaslConfig{Mechanism: "PLAIN", Username: "SOURCEKEY", Password: "source-secret"}
+	link := CreateClusterLinkRequest{
+		SourceClusterId: "lkc-eu",
+		Configs:         []KafkaConfig{{Name: "sasl.jaas.config", Value: sasl.JaasConfig()}},
+	}
+	if err := config.createClusterLink(cluster, ClusterLinkCredentials{ApiKey: "KEY", ApiSecret: "SECRET"}, "eu-to-us", link); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(received, "source-secret") {
+		t.Errorf("expected the source secret to be sent, got %s", received)
+	}
+	if strings.Contains(logs.String(), "source-secret") {
+		t.Errorf("the source secret was logged:\n%s", logs.String())
+	}
+}
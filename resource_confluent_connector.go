@@ -0,0 +1,309 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"strings"
+	"time"
+)
+
+func resourceConnector() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConnectorCreate,
+		Read:   resourceConnectorRead,
+		Update: resourceConnectorUpdate,
+		Delete: resourceConnectorDelete,
+
+		CustomizeDiff: resourceConnectorCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+			},
+			"cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"config": &schema.Schema{
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Connector configuration, including connector.class",
+			},
+			"config_sensitive": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Sensitive part of the connector configuration (passwords, API secrets, ...)",
+			},
+			"status": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RUNNING",
+				ValidateFunc: validation.StringInSlice([]string{"RUNNING", "PAUSED"}, false),
+				Description:  "RUNNING or PAUSED",
+			},
+			"task_states": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func getConnectorConfig(d resourceGetter) map[string]string {
+	config := map[string]string{
+		"name": d.Get("name").(string),
+	}
+	for k, v := range d.Get("config").(map[string]interface{}) {
+		config[k] = v.(string)
+	}
+	for k, v := range d.Get("config_sensitive").(map[string]interface{}) {
+		config[k] = v.(string)
+	}
+	return config
+}
+
+// resourceConnectorCustomizeDiff validates the configuration with the connector plugin so that errors show up during plan
+func resourceConnectorCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !(d.Id() == "" || d.HasChange("config") || d.HasChange("config_sensitive")) {
+		return nil
+	}
+	for _, key := range []string{"account_id", "cluster_id", "name", "config", "config_sensitive"} {
+		if !d.NewValueKnown(key) {
+			log.Print("Unable to validate connector configuration during plan, " + key + " is not known yet")
+			return nil
+		}
+	}
+	connectorConfig := getConnectorConfig(d)
+	class, classSet := connectorConfig["connector.class"]
+	if !classSet {
+		return errors.New("connector.class must be set in config")
+	}
+
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	cluster, err := config.getClusterPerAccount(accountId.(string), d.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+
+	configValidation, err := config.validateConnectorConfig(*cluster, class, connectorConfig)
+	if err != nil {
+		return err
+	}
+	if configValidation.ErrorCount > 0 {
+		return errors.New("Invalid configuration for connector " + d.Get("name").(string) + ":\n" + strings.Join(configValidation.Errors(), "\n"))
+	}
+	return nil
+}
+
+// waitForConnector waits until the connector and its tasks reach the managed status, failing with the task traces
+func waitForConnector(config *Config, cluster Cluster, name string, target string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PROVISIONING", "UNASSIGNED", "RUNNING", "PAUSED"},
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			status, err := config.getConnectorStatus(cluster, name)
+			if err != nil {
+				return nil, "", err
+			}
+			if failures := status.Failures(); len(failures) > 0 {
+				return nil, "FAILED", errors.New("Connector " + name + " failed:\n" + strings.Join(failures, "\n"))
+			}
+			for _, task := range status.Tasks {
+				if task.State != target {
+					return status, task.State, nil
+				}
+			}
+			return status, status.Connector.State, nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func resourceConnectorCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+	log.Print("Creating connector " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
+
+	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	if err := config.createConnector(*cluster, name, getConnectorConfig(d)); err != nil {
+		return err
+	}
+	d.SetId(accountId.(string) + "-" + clusterId + "-" + name)
+
+	if d.Get("status").(string) == "PAUSED" {
+		if err := config.pauseConnector(*cluster, name); err != nil {
+			return err
+		}
+	}
+	if err := waitForConnector(config, *cluster, name, d.Get("status").(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+	return resourceConnectorRead(d, m)
+}
+
+func resourceConnectorRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+
+	cluster, err := config.findClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		log.Print("Unable to find cluster " + clusterId + " of connector " + name)
+		d.SetId("")
+		return nil
+	}
+	remoteConfig, err := config.getConnectorConfig(*cluster, name)
+	if err != nil {
+		return err
+	}
+	if remoteConfig == nil {
+		log.Print("Unable to find connector " + name + " in cluster " + clusterId)
+		d.SetId("")
+		return nil
+	}
+
+	// Only keys managed by Terraform are read back, the service adds its own defaults
+	// and never returns sensitive values
+	managedConfig := map[string]string{}
+	for k := range d.Get("config").(map[string]interface{}) {
+		if v, ok := remoteConfig[k]; ok {
+			managedConfig[k] = v
+		}
+	}
+	d.Set("config", managedConfig)
+
+	status, err := config.getConnectorStatus(*cluster, name)
+	if err != nil {
+		return err
+	}
+	if status.Connector.State == "RUNNING" || status.Connector.State == "PAUSED" {
+		d.Set("status", status.Connector.State)
+	} else {
+		log.Print("Connector " + name + " is " + status.Connector.State)
+	}
+	var taskStates []string
+	for _, task := range status.Tasks {
+		taskStates = append(taskStates, task.State)
+	}
+	d.Set("task_states", taskStates)
+
+	return nil
+}
+
+func resourceConnectorUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+	log.Print("Updating connector " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
+
+	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	if d.HasChange("config") || d.HasChange("config_sensitive") {
+		if err := config.updateConnectorConfig(*cluster, name, getConnectorConfig(d)); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("status") {
+		if d.Get("status").(string) == "PAUSED" {
+			err = config.pauseConnector(*cluster, name)
+		} else {
+			err = config.resumeConnector(*cluster, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := waitForConnector(config, *cluster, name, d.Get("status").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+	return resourceConnectorRead(d, m)
+}
+
+func resourceConnectorDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+	log.Print("Deleting connector " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
+
+	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	if err := config.deleteConnector(*cluster, name); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testConnectedConfig returns a Config that skips the login and lists clusters from the handler
+func testConnectedConfig(t *testing.T, clusters http.HandlerFunc) *Config {
+	server := httptest.NewServer(clusters)
+	t.Cleanup(server.Close)
+	return &Config{
+		ApiEndpoint: server.URL,
+		Session:     &Session{Token: "session"},
+		AccessToken: &AccessToken{Token: "token"},
+		Me:          &Me{Account: Account{Id: "env-1"}},
+	}
+}
+
+func TestConnectorReadClusterErrors(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConnector().Schema, map[string]interface{}{
+		"cluster_id": "lkc-1",
+		"name":       "payments-s3-sink",
+		"config":     map[string]interface{}{"connector.class": "S3_SINK"},
+	})
+	d.SetId("lkc-1/payments-s3-sink")
+
+	unavailable := testConnectedConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	if err := resourceConnectorRead(d, unavailable); err == nil || d.Id() == "" {
+		t.Errorf("expected the error to be returned and the connector kept, got %v", err)
+	}
+
+	deleted := testConnectedConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"clusters":[]}`))
+	})
+	if err := resourceConnectorRead(d, deleted); err != nil || d.Id() != "" {
+		t.Errorf("expected a connector of a deleted cluster to be removed from state, got %v", err)
+	}
+}
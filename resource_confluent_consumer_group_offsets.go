@@ -0,0 +1,295 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func resourceConsumerGroupOffsets() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConsumerGroupOffsetsApply,
+		Read:   resourceConsumerGroupOffsetsRead,
+		Update: resourceConsumerGroupOffsetsUpdate,
+		Delete: resourceConsumerGroupOffsetsDelete,
+
+		CustomizeDiff: resourceConsumerGroupOffsetsCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+			},
+			"cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"topic": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cluster API key used to commit and read the offsets, the REST API cannot do it",
+			},
+			"api_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"reset_to": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"earliest", "latest", "timestamp", "explicit"}, false),
+				Description:  "earliest, latest, timestamp or explicit",
+			},
+			"timestamp": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+				Description:  "RFC3339 date to reset to, when reset_to is timestamp",
+			},
+			"partition_offset": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Offsets to set, when reset_to is explicit",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"offset": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"keepers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that apply the reset again when changed",
+			},
+			"enforce": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Apply the reset again whenever the committed offsets differ from the applied ones",
+			},
+			"applied_offsets": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"offset": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"applied_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"committed_offsets": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Offsets currently committed by the group on the topic",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"offset": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenPartitionOffsets(offsets []PartitionOffset) []map[string]interface{} {
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i].Partition < offsets[j].Partition })
+	var result []map[string]interface{}
+	for _, partitionOffset := range offsets {
+		result = append(result, map[string]interface{}{
+			"partition": partitionOffset.Partition,
+			"offset":    int(partitionOffset.Offset),
+		})
+	}
+	return result
+}
+
+// offsetsDrifted returns true when a partition reset by the resource no longer has the offset applied
+func offsetsDrifted(applied interface{}, committed interface{}) bool {
+	committedOffsets := map[int]int{}
+	for _, partitionOffset := range committed.([]interface{}) {
+		p := partitionOffset.(map[string]interface{})
+		committedOffsets[p["partition"].(int)] = p["offset"].(int)
+	}
+	for _, partitionOffset := range applied.([]interface{}) {
+		p := partitionOffset.(map[string]interface{})
+		if offset, exists := committedOffsets[p["partition"].(int)]; !exists || offset != p["offset"].(int) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceConsumerGroupOffsetsCustomizeDiff plans the reset again when enforce is set and the offsets moved
+func resourceConsumerGroupOffsetsCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.Get("enforce").(bool) {
+		return nil
+	}
+	if offsetsDrifted(d.Get("applied_offsets"), d.Get("committed_offsets")) {
+		log.Print("Committed offsets of consumer group " + d.Get("group_id").(string) + " differ from the applied ones")
+		return d.SetNewComputed("applied_offsets")
+	}
+	return nil
+}
+
+func getResetOffsetsRequest(d *schema.ResourceData) (*ResetOffsetsRequest, error) {
+	resetTo := d.Get("reset_to").(string)
+	reset := ResetOffsetsRequest{Strategy: resetTo}
+	switch resetTo {
+	case "timestamp":
+		timestamp, timestampSet := d.GetOk("timestamp")
+		if !timestampSet {
+			return nil, errors.New("timestamp must be set to reset offsets to a timestamp")
+		}
+		reset.Timestamp = mustParseRFC3339(timestamp.(string)).UnixNano() / int64(time.Millisecond)
+	case "explicit":
+		for _, partitionOffset := range d.Get("partition_offset").([]interface{}) {
+			p := partitionOffset.(map[string]interface{})
+			reset.Offsets = append(reset.Offsets, PartitionOffset{
+				Partition: p["partition"].(int),
+				Offset:    int64(p["offset"].(int)),
+			})
+		}
+		if len(reset.Offsets) == 0 {
+			return nil, errors.New("partition_offset must be set to reset offsets explicitly")
+		}
+	}
+	return &reset, nil
+}
+
+// resourceConsumerGroupOffsetsApply resets the offsets, refusing while consumers are connected to the group
+func resourceConsumerGroupOffsetsApply(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	clusterId := d.Get("cluster_id").(string)
+	groupId := d.Get("group_id").(string)
+	topic := d.Get("topic").(string)
+
+	reset, err := getResetOffsetsRequest(d)
+	if err != nil {
+		return err
+	}
+	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
+	if err != nil {
+		return err
+	}
+	consumers, err := config.getConsumers(*cluster, groupId)
+	if err != nil {
+		return err
+	}
+	if len(consumers) > 0 {
+		return errors.New("Unable to reset offsets of consumer group " + groupId + ": it still has " + strconv.Itoa(len(consumers)) + " active member(s)")
+	}
+
+	log.Print("Resetting offsets of consumer group " + groupId + " on topic " + topic + " to " + reset.Strategy)
+	applied, err := resetConsumerGroupOffsets(*cluster, d.Get("api_key").(string), d.Get("api_secret").(string), groupId, topic, *reset)
+	if err != nil {
+		return err
+	}
+	for _, partitionOffset := range applied {
+		log.Print("Partition " + strconv.Itoa(partitionOffset.Partition) + " reset to offset " + strconv.FormatInt(partitionOffset.Offset, 10))
+	}
+	d.SetId(accountId.(string) + "-" + clusterId + "-" + groupId + "-" + topic)
+	if err := d.Set("applied_offsets", flattenPartitionOffsets(applied)); err != nil {
+		return err
+	}
+	d.Set("applied_at", time.Now().UTC().Format(time.RFC3339))
+	return resourceConsumerGroupOffsetsRead(d, m)
+}
+
+// resourceConsumerGroupOffsetsRead records the committed offsets, consumers move them as soon as they resume
+func resourceConsumerGroupOffsetsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	cluster, err := config.findClusterPerAccount(accountId.(string), d.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		log.Print("Unable to find cluster " + d.Get("cluster_id").(string) + ", forgetting the offsets reset")
+		d.SetId("")
+		return nil
+	}
+	committed, err := getCommittedOffsets(*cluster, d.Get("api_key").(string), d.Get("api_secret").(string), d.Get("group_id").(string), d.Get("topic").(string))
+	if err != nil {
+		return err
+	}
+	return d.Set("committed_offsets", flattenPartitionOffsets(committed))
+}
+
+// resourceConsumerGroupOffsetsUpdate resets the offsets again when the reset changed or, with enforce, when they drifted
+func resourceConsumerGroupOffsetsUpdate(d *schema.ResourceData, m interface{}) error {
+	reapply := false
+	for _, key := range []string{"reset_to", "timestamp", "partition_offset", "keepers"} {
+		reapply = reapply || d.HasChange(key)
+	}
+	applied, _ := d.GetChange("applied_offsets")
+	if d.Get("enforce").(bool) && offsetsDrifted(applied, d.Get("committed_offsets")) {
+		reapply = true
+	}
+	if reapply {
+		return resourceConsumerGroupOffsetsApply(d, m)
+	}
+	return resourceConsumerGroupOffsetsRead(d, m)
+}
+
+// resourceConsumerGroupOffsetsDelete only forgets the reset, offsets cannot be restored
+func resourceConsumerGroupOffsetsDelete(d *schema.ResourceData, m interface{}) error {
+	log.Print("Removing offsets reset of consumer group " + d.Get("group_id").(string) + " from Terraform, offsets are left unchanged")
+	d.SetId("")
+	return nil
+}
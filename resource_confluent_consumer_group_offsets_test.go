@@ -0,0 +1,144 @@
+package main
+
+import (
+	"github.com/Shopify/sarama"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"net/http"
+	"testing"
+)
+
+func TestOffsetsDrifted(t *testing.T) {
+	applied := []interface{}{
+		map[string]interface{}{"partition": 0, "offset": 10},
+		map[string]interface{}{"partition": 1, "offset": 20},
+	}
+	cases := []struct {
+		committed []interface{}
+		drifted   bool
+	}{
+		{[]interface{}{
+			map[string]interface{}{"partition": 0, "offset": 10},
+			map[string]interface{}{"partition": 1, "offset": 20},
+			map[string]interface{}{"partition": 2, "offset": 99},
+		}, false},
+		{[]interface{}{
+			map[string]interface{}{"partition": 0, "offset": 10},
+			map[string]interface{}{"partition": 1, "offset": 25},
+		}, true},
+		{[]interface{}{
+			map[string]interface{}{"partition": 0, "offset": 10},
+		}, true},
+		{[]interface{}{}, true},
+	}
+	for i, c := range cases {
+		if drifted := offsetsDrifted(applied, c.committed); drifted != c.drifted {
+			t.Errorf("case %d: offsetsDrifted = %v, expected %v", i, drifted, c.drifted)
+		}
+	}
+}
+
+func TestFlattenPartitionOffsets(t *testing.T) {
+	flattened := flattenPartitionOffsets([]PartitionOffset{{Partition: 2, Offset: 7}, {Partition: 0, Offset: 3}})
+	if len(flattened) != 2 || flattened[0]["partition"] != 0 || flattened[0]["offset"] != 3 || flattened[1]["partition"] != 2 {
+		t.Errorf("unexpected flattened offsets: %v", flattened)
+	}
+}
+
+// testKafkaBroker serves the metadata of a two partition payments topic, the archiver group is coordinated by the same broker
+func testKafkaBroker(t *testing.T, committed *sarama.MockOffsetFetchResponse) (*sarama.MockBroker, sarama.Client) {
+	broker := sarama.NewMockBroker(t, 1)
+	t.Cleanup(broker.Close)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("payments", 0, broker.BrokerID()).
+			SetLeader("payments", 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetVersion(1).
+			SetOffset("payments", 0, sarama.OffsetOldest, 5).
+			SetOffset("payments", 1, sarama.OffsetOldest, 7).
+			SetOffset("payments", 0, sarama.OffsetNewest, 50).
+			SetOffset("payments", 1, sarama.OffsetNewest, 70),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).SetCoordinator(sarama.CoordinatorGroup, "archiver", broker),
+		"OffsetFetchRequest":     committed,
+		"OffsetCommitRequest":    sarama.NewMockOffsetCommitResponse(t),
+	})
+	client, err := sarama.NewClient([]string{broker.Addr()}, kafkaClientConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return broker, client
+}
+
+func TestCommitOffsets(t *testing.T) {
+	committed := sarama.NewMockOffsetFetchResponse(t).
+		SetOffset("archiver", "payments", 0, 40, "", sarama.ErrNoError).
+		SetOffset("archiver", "payments", 1, 60, "", sarama.ErrNoError)
+	broker, client := testKafkaBroker(t, committed)
+
+	applied, err := commitOffsets(client, "archiver", "payments", ResetOffsetsRequest{Strategy: "earliest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[int]int64{0: 5, 1: 7}
+	if len(applied) != 2 || applied[0].Offset != expected[applied[0].Partition] || applied[1].Offset != expected[applied[1].Partition] {
+		t.Errorf("unexpected applied offsets: %v", applied)
+	}
+
+	commits := map[int32]int64{}
+	for _, exchange := range broker.History() {
+		if request, ok := exchange.Request.(*sarama.OffsetCommitRequest); ok {
+			for partition := range expected {
+				if offset, _, err := request.Offset("payments", int32(partition)); err == nil {
+					commits[int32(partition)] = offset
+				}
+			}
+		}
+	}
+	if commits[0] != 5 || commits[1] != 7 {
+		t.Errorf("expected offsets 5 and 7 to be committed, got %v", commits)
+	}
+}
+
+func TestFetchCommittedOffsets(t *testing.T) {
+	// Partition 1 has no committed offset yet
+	committed := sarama.NewMockOffsetFetchResponse(t).
+		SetOffset("archiver", "payments", 0, 42, "", sarama.ErrNoError).
+		SetOffset("archiver", "payments", 1, -1, "", sarama.ErrNoError)
+	_, client := testKafkaBroker(t, committed)
+
+	offsets, err := fetchCommittedOffsets(client, "archiver", "payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(offsets) != 1 || offsets[0].Partition != 0 || offsets[0].Offset != 42 {
+		t.Errorf("unexpected committed offsets: %v", offsets)
+	}
+}
+
+func TestConsumerGroupOffsetsReadClusterErrors(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConsumerGroupOffsets().Schema, map[string]interface{}{
+		"cluster_id": "lkc-1",
+		"group_id":   "archiver",
+		"topic":      "payments",
+		"api_key":    "KEY",
+		"api_secret": "SECRET",
+		"reset_to":   "earliest",
+	})
+	d.SetId("env-1-lkc-1-archiver-payments")
+
+	unavailable := testConnectedConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	if err := resourceConsumerGroupOffsetsRead(d, unavailable); err == nil || d.Id() == "" {
+		t.Errorf("expected the error to be returned and the reset kept, got %v", err)
+	}
+
+	deleted := testConnectedConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"clusters":[]}`))
+	})
+	if err := resourceConsumerGroupOffsetsRead(d, deleted); err != nil || d.Id() != "" {
+		t.Errorf("expected the reset of a deleted cluster to be removed from state, got %v", err)
+	}
+}
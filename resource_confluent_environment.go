@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"strconv"
+)
+
+func resourceEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEnvironmentCreate,
+		Read:   resourceEnvironmentRead,
+		Update: resourceEnvironmentUpdate,
+		Delete: resourceEnvironmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"organization_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deactivated": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"modified": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"internal": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	log.Print("Creating environment " + name)
+	account, err := config.createAccount(name)
+	if err != nil {
+		return err
+	}
+	// Lookups and imports go through Me.Accounts, make the new environment visible to them
+	if err := config.refreshMe(); err != nil {
+		return err
+	}
+
+	d.SetId(account.Id)
+	return resourceEnvironmentRead(d, m)
+}
+
+func resourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	account, err := config.getAccount(d.Id())
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		log.Print("Unable to find environment with ID " + d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", account.Name)
+	d.Set("organization_id", strconv.Itoa(account.OrganizationId))
+	d.Set("deactivated", account.Deactivated)
+	d.Set("created", account.Created)
+	d.Set("modified", account.Modified)
+	d.Set("internal", account.Internal)
+
+	return nil
+}
+
+func resourceEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	account, err := config.getAccount(d.Id())
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.New("Unable to find environment with ID " + d.Id())
+	}
+
+	_, err = config.updateAccount(*account, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+	if err := config.refreshMe(); err != nil {
+		return err
+	}
+	return resourceEnvironmentRead(d, m)
+}
+
+func resourceEnvironmentDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	account, err := config.getAccount(d.Id())
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		d.SetId("")
+		return nil
+	}
+
+	clusters, err := config.getClustersPerAccount(account.Id)
+	if err != nil {
+		return err
+	}
+	if len(clusters.Clusters) > 0 {
+		return errors.New("Unable to delete environment " + account.Name + ": it still contains " + strconv.Itoa(len(clusters.Clusters)) + " cluster(s)")
+	}
+
+	errDeleteAccount := config.deleteAccount(*account)
+	if errDeleteAccount != nil {
+		return errDeleteAccount
+	}
+	if err := config.refreshMe(); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"strconv"
+)
+
+func resourceKafkaClientQuota() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKafkaClientQuotaCreate,
+		Read:   resourceKafkaClientQuotaRead,
+		Update: resourceKafkaClientQuotaUpdate,
+		Delete: resourceKafkaClientQuotaDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Computed: true,
+				Optional: true,
+			},
+			"cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"display_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"principals": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Service account IDs (sa-xxxxx) sharing the quota",
+			},
+			"producer_byte_rate": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum bytes per second produced by the principals",
+			},
+			"consumer_byte_rate": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum bytes per second fetched by the principals",
+			},
+		},
+	}
+}
+
+// getClientQuotaSpec builds the whole quota, the API has no way to leave a rate unlimited
+func getClientQuotaSpec(d *schema.ResourceData, accountId string) ClientQuotaSpec {
+	spec := ClientQuotaSpec{
+		DisplayName: d.Get("display_name").(string),
+		Description: d.Get("description").(string),
+		Throughput: ClientQuotaThroughput{
+			IngressByteRate: strconv.Itoa(d.Get("producer_byte_rate").(int)),
+			EgressByteRate:  strconv.Itoa(d.Get("consumer_byte_rate").(int)),
+		},
+		Cluster:     ClientQuotaReference{Id: d.Get("cluster_id").(string)},
+		Environment: ClientQuotaReference{Id: accountId},
+	}
+	for _, principal := range d.Get("principals").(*schema.Set).List() {
+		spec.Principals = append(spec.Principals, ClientQuotaReference{Id: principal.(string)})
+	}
+	return spec
+}
+
+func resourceKafkaClientQuotaCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	spec := getClientQuotaSpec(d, accountId.(string))
+	log.Print("Creating client quota " + spec.DisplayName + " in cluster " + spec.Cluster.Id)
+	quota, err := config.createClientQuota(spec)
+	if err != nil {
+		return err
+	}
+	d.SetId(quota.Id)
+	return resourceKafkaClientQuotaRead(d, m)
+}
+
+func resourceKafkaClientQuotaRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	quota, err := config.getClientQuota(d.Id())
+	if err != nil {
+		return err
+	}
+	if quota == nil {
+		log.Print("Unable to find client quota " + d.Id())
+		d.SetId("")
+		return nil
+	}
+	return setClientQuota(d, *quota)
+}
+
+func setClientQuota(d *schema.ResourceData, quota ClientQuota) error {
+	producerByteRate, err := strconv.Atoi(quota.Spec.Throughput.IngressByteRate)
+	if err != nil {
+		return err
+	}
+	consumerByteRate, err := strconv.Atoi(quota.Spec.Throughput.EgressByteRate)
+	if err != nil {
+		return err
+	}
+	var principals []interface{}
+	for _, principal := range quota.Spec.Principals {
+		principals = append(principals, principal.Id)
+	}
+
+	d.Set("account_id", quota.Spec.Environment.Id)
+	d.Set("cluster_id", quota.Spec.Cluster.Id)
+	d.Set("display_name", quota.Spec.DisplayName)
+	d.Set("description", quota.Spec.Description)
+	d.Set("principals", schema.NewSet(schema.HashString, principals))
+	d.Set("producer_byte_rate", producerByteRate)
+	d.Set("consumer_byte_rate", consumerByteRate)
+
+	return nil
+}
+
+func resourceKafkaClientQuotaUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	spec := getClientQuotaSpec(d, d.Get("account_id").(string))
+	log.Print("Updating client quota " + d.Id())
+	if err := config.updateClientQuota(d.Id(), spec); err != nil {
+		return err
+	}
+	return resourceKafkaClientQuotaRead(d, m)
+}
+
+func resourceKafkaClientQuotaDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	log.Print("Removing client quota " + d.Id())
+	if err := config.deleteClientQuota(d.Id()); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
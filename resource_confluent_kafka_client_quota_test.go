@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// clientQuotasStandIn serves the kafka-quotas/v1 client-quotas endpoints from memory
+type clientQuotasStandIn struct {
+	mutex    sync.Mutex
+	quotas   map[string]ClientQuota
+	payloads []map[string]interface{}
+}
+
+func (s *clientQuotasStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if user, _, ok := r.BasicAuth(); !ok || user != "CLOUDKEY" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/kafka-quotas/v1/client-quotas"), "/")
+	var body map[string]interface{}
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+	_, exists := s.quotas[id]
+	switch {
+	case r.Method == "POST" && id == "":
+		s.payloads = append(s.payloads, body)
+		quota := decodeClientQuota(body)
+		quota.Id = "cq-1"
+		s.quotas[quota.Id] = quota
+		json.NewEncoder(w).Encode(quota)
+	case !exists:
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == "GET":
+		json.NewEncoder(w).Encode(s.quotas[id])
+	case r.Method == "PATCH":
+		s.payloads = append(s.payloads, body)
+		quota := decodeClientQuota(body)
+		quota.Id = id
+		s.quotas[id] = quota
+		json.NewEncoder(w).Encode(quota)
+	case r.Method == "DELETE":
+		delete(s.quotas, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func decodeClientQuota(body map[string]interface{}) ClientQuota {
+	var quota ClientQuota
+	raw, _ := json.Marshal(body)
+	json.Unmarshal(raw, &quota)
+	return quota
+}
+
+func TestClientQuotaLifecycle(t *testing.T) {
+	standIn := &clientQuotasStandIn{quotas: map[string]ClientQuota{}}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	config := &Config{CloudApiEndpoint: server.URL, CloudApiKey: "CLOUDKEY", CloudApiSecret: "secret"}
+
+	d := schema.TestResourceDataRaw(t, resourceKafkaClientQuota().Schema, map[string]interface{}{
+		"cluster_id":         "lkc-1",
+		"display_name":       "reporting",
+		"principals":         []interface{}{"sa-1", "sa-2"},
+		"producer_byte_rate": 1048576,
+		"consumer_byte_rate": 5242880,
+	})
+	quota, err := config.createClientQuota(getClientQuotaSpec(d, "env-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	throughput := standIn.payloads[0]["spec"].(map[string]interface{})["throughput"].(map[string]interface{})
+	if throughput["ingress_byte_rate"] != "1048576" || throughput["egress_byte_rate"] != "5242880" {
+		t.Errorf("unexpected throughput sent: %v", throughput)
+	}
+
+	d.SetId(quota.Id)
+	d.Set("consumer_byte_rate", 1024)
+	if err := config.updateClientQuota(d.Id(), getClientQuotaSpec(d, "env-1")); err != nil {
+		t.Fatal(err)
+	}
+	read, err := config.getClientQuota(d.Id())
+	if err != nil || read == nil {
+		t.Fatalf("expected the quota to be read back, got %v %v", read, err)
+	}
+	if err := setClientQuota(d, *read); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("consumer_byte_rate").(int) != 1024 || d.Get("producer_byte_rate").(int) != 1048576 {
+		t.Errorf("unexpected rates read back: %v %v", d.Get("producer_byte_rate"), d.Get("consumer_byte_rate"))
+	}
+	if d.Get("account_id").(string) != "env-1" || d.Get("principals").(*schema.Set).Len() != 2 {
+		t.Errorf("unexpected quota read back: %v", read)
+	}
+
+	if err := config.deleteClientQuota(d.Id()); err != nil {
+		t.Fatal(err)
+	}
+	if read, err := config.getClientQuota(d.Id()); err != nil || read != nil {
+		t.Errorf("expected a deleted quota to be nil, got %v %v", read, err)
+	}
+}
+
+func TestClientQuotaWithoutCloudApiKey(t *testing.T) {
+	config := &Config{CloudApiEndpoint: "http://127.0.0.1:0"}
+	if _, err := config.getClientQuota("cq-1"); err == nil || !strings.Contains(err.Error(), "cloud_api_key") {
+		t.Errorf("expected an error about the missing Cloud API key, got %v", err)
+	}
+}
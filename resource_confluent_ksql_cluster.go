@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"time"
+)
+
+func resourceKsqlCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsqlClusterCreate,
+		Read:   resourceKsqlClusterRead,
+		Delete: resourceKsqlClusterDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Computed: true,
+				Optional: true,
+			},
+			"kafka_cluster_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Kafka cluster used by the ksqlDB application",
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"csu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{1, 2, 4, 8, 12}),
+				Description:  "Number of Confluent Streaming Units: 1, 2, 4, 8 or 12",
+			},
+			"credential_identity": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the service account (or user) the application runs as",
+			},
+			"use_detailed_processing_log": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Include row data in the processing log",
+			},
+			"rest_endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"processing_log_topic": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"physical_cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"storage": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKsqlClusterCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	name := d.Get("name").(string)
+	log.Print("Creating ksqlDB cluster " + name + " in account " + accountId.(string))
+
+	ksqlCluster, err := config.createKsqlCluster(
+		accountId.(string),
+		d.Get("kafka_cluster_id").(string),
+		name,
+		d.Get("csu").(int),
+		d.Get("credential_identity").(int),
+		d.Get("use_detailed_processing_log").(bool),
+	)
+	if err != nil {
+		return err
+	}
+	d.SetId(ksqlCluster.Id)
+	d.Set("account_id", accountId.(string))
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PROVISIONING", ""},
+		Target:  []string{"UP"},
+		Refresh: func() (interface{}, string, error) {
+			ksqlCluster, err := config.getKsqlCluster(accountId.(string), d.Id())
+			if err != nil {
+				return nil, "", err
+			}
+			if ksqlCluster == nil {
+				return nil, "", errors.New("ksqlDB cluster " + d.Id() + " disappeared while provisioning")
+			}
+			return ksqlCluster, ksqlCluster.Status, nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+	return resourceKsqlClusterRead(d, m)
+}
+
+func resourceKsqlClusterRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	ksqlCluster, err := config.getKsqlCluster(accountId.(string), d.Id())
+	if err != nil {
+		return err
+	}
+	if ksqlCluster == nil {
+		log.Print("Unable to find ksqlDB cluster " + d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("account_id", accountId.(string))
+	d.Set("name", ksqlCluster.Name)
+	d.Set("kafka_cluster_id", ksqlCluster.KafkaClusterId)
+	d.Set("csu", ksqlCluster.TotalNumCsu)
+	d.Set("credential_identity", ksqlCluster.ServiceAccountId)
+	d.Set("use_detailed_processing_log", ksqlCluster.UseDetailedProcessingLog)
+	d.Set("rest_endpoint", ksqlCluster.Endpoint)
+	d.Set("processing_log_topic", ksqlCluster.ProcessingLogTopic())
+	d.Set("physical_cluster_id", ksqlCluster.PhysicalClusterId)
+	d.Set("status", ksqlCluster.Status)
+	d.Set("storage", ksqlCluster.Storage)
+
+	return nil
+}
+
+func resourceKsqlClusterDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	ksqlCluster, err := config.getKsqlCluster(accountId.(string), d.Id())
+	if err != nil {
+		return err
+	}
+	if ksqlCluster != nil {
+		if err := config.deleteKsqlCluster(*ksqlCluster); err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// ksqlCreateStatement extracts the kind and the name of the source created by a statement
+var ksqlCreateStatement = regexp.MustCompile("(?is)^\\s*CREATE\\s+(?:OR\\s+REPLACE\\s+)?(STREAM|TABLE)\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?(`[^`]+`|[A-Za-z_][A-Za-z0-9_]*)")
+
+func resourceKsqlStatement() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsqlStatementCreate,
+		Read:   resourceKsqlStatementRead,
+		Update: resourceKsqlStatementUpdate,
+		Delete: resourceKsqlStatementDelete,
+
+		Schema: map[string]*schema.Schema{
+			"rest_endpoint": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "REST endpoint of the ksqlDB application",
+			},
+			"api_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"api_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"statement": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKsqlStatement,
+				Description:  "CREATE STREAM or CREATE TABLE statement",
+			},
+			"properties": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Streams properties used to run the statement (eg: auto.offset.reset)",
+			},
+			"delete_topic": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also delete the backing topic on destroy",
+			},
+			"source_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"topic": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"query_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func validateKsqlStatement(v interface{}, k string) (ws []string, errs []error) {
+	if !ksqlCreateStatement.MatchString(v.(string)) {
+		errs = append(errs, errors.New(k+": only CREATE STREAM and CREATE TABLE statements are supported"))
+	}
+	return
+}
+
+func ksqlClient(d *schema.ResourceData) *KsqlClient {
+	return &KsqlClient{
+		Endpoint:  d.Get("rest_endpoint").(string),
+		ApiKey:    d.Get("api_key").(string),
+		ApiSecret: d.Get("api_secret").(string),
+	}
+}
+
+func getKsqlProperties(d *schema.ResourceData) map[string]string {
+	properties := map[string]string{}
+	for k, v := range d.Get("properties").(map[string]interface{}) {
+		properties[k] = v.(string)
+	}
+	return properties
+}
+
+func resourceKsqlStatementCreate(d *schema.ResourceData, m interface{}) error {
+	client := ksqlClient(d)
+	statement := strings.TrimSpace(d.Get("statement").(string))
+	if !strings.HasSuffix(statement, ";") {
+		statement += ";"
+	}
+	matches := ksqlCreateStatement.FindStringSubmatch(statement)
+	sourceType := strings.ToUpper(matches[1])
+	sourceName := matches[2]
+	if !strings.HasPrefix(sourceName, "`") {
+		sourceName = strings.ToUpper(sourceName)
+	}
+
+	log.Print("Creating ksqlDB " + sourceType + " " + sourceName)
+	entities, ksqlError, err := client.executeStatement(statement, getKsqlProperties(d))
+	if err != nil {
+		return err
+	}
+	if ksqlError != nil {
+		return errors.New("ksqlDB rejected the statement: " + ksqlError.Message)
+	}
+	for _, entity := range entities {
+		if entity.CommandStatus.Status == "ERROR" {
+			return errors.New("ksqlDB failed to run the statement: " + entity.CommandStatus.Message)
+		}
+	}
+
+	d.SetId(sourceName)
+	d.Set("source_name", sourceName)
+	d.Set("source_type", sourceType)
+	return resourceKsqlStatementRead(d, m)
+}
+
+// resourceKsqlStatementRead describes the stream or table to detect a drop outside Terraform
+func resourceKsqlStatementRead(d *schema.ResourceData, m interface{}) error {
+	client := ksqlClient(d)
+	entities, ksqlError, err := client.executeStatement("DESCRIBE "+d.Id()+";", nil)
+	if err != nil {
+		return err
+	}
+	if ksqlError != nil {
+		if ksqlError.SourceNotFound() {
+			log.Print("Unable to find ksqlDB source " + d.Id())
+			d.SetId("")
+			return nil
+		}
+		return errors.New("Unable to describe ksqlDB source " + d.Id() + ": " + ksqlError.Message)
+	}
+
+	for _, entity := range entities {
+		if entity.Type != "sourceDescription" {
+			continue
+		}
+		var queryIds []string
+		for _, query := range entity.SourceDescription.WriteQueries {
+			queryIds = append(queryIds, query.Id)
+		}
+		d.Set("source_type", entity.SourceDescription.Type)
+		d.Set("topic", entity.SourceDescription.Topic)
+		d.Set("query_ids", queryIds)
+	}
+	return nil
+}
+
+// resourceKsqlStatementUpdate only stores the new credentials and flags, the statement itself forces a new resource
+func resourceKsqlStatementUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceKsqlStatementRead(d, m)
+}
+
+// resourceKsqlStatementDelete terminates the persistent queries writing to the source, then drops it
+func resourceKsqlStatementDelete(d *schema.ResourceData, m interface{}) error {
+	client := ksqlClient(d)
+	if err := resourceKsqlStatementRead(d, m); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, queryId := range d.Get("query_ids").([]interface{}) {
+		log.Print("Terminating ksqlDB query " + queryId.(string))
+		_, ksqlError, err := client.executeStatement("TERMINATE "+queryId.(string)+";", nil)
+		if err != nil {
+			return err
+		}
+		if ksqlError != nil {
+			return errors.New("Unable to terminate ksqlDB query " + queryId.(string) + ": " + ksqlError.Message)
+		}
+	}
+
+	drop := "DROP " + d.Get("source_type").(string) + " " + d.Id()
+	if d.Get("delete_topic").(bool) {
+		drop += " DELETE TOPIC"
+	}
+	log.Print(drop)
+	_, ksqlError, err := client.executeStatement(drop+";", nil)
+	if err != nil {
+		return err
+	}
+	if ksqlError != nil && !ksqlError.SourceNotFound() {
+		return errors.New("Unable to drop ksqlDB source " + d.Id() + ": " + ksqlError.Message)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type ksqlStandInSource struct {
+	Type         string
+	ReadQueries  []string
+	WriteQueries []string
+}
+
+// ksqlStandIn is a local stand-in of the ksqlDB /ksql endpoint, keeping streams and tables in memory
+type ksqlStandIn struct {
+	mutex      sync.Mutex
+	sources    map[string]*ksqlStandInSource
+	statements []string
+	// failures returns an HTTP status for statements starting with the key
+	failures map[string]int
+}
+
+var ksqlStandInStatement = regexp.MustCompile(`(?is)^(?:(CREATE STREAM|CREATE TABLE)\s+([A-Za-z0-9_]+)\s.*|(DESCRIBE|DROP STREAM|DROP TABLE|TERMINATE)\s+([A-Za-z0-9_]+)(?:\s+DELETE TOPIC)?;)$`)
+
+func (s *ksqlStandIn) reply(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.ksql.v1+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *ksqlStandIn) badStatement(w http.ResponseWriter, message string) {
+	s.reply(w, http.StatusBadRequest, KsqlError{Type: "statement_error", ErrorCode: 40001, Message: message})
+}
+
+func (s *ksqlStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var request KsqlRequest
+	json.NewDecoder(r.Body).Decode(&request)
+	s.statements = append(s.statements, request.Ksql)
+	for prefix, status := range s.failures {
+		if strings.HasPrefix(request.Ksql, prefix) {
+			w.WriteHeader(status)
+			return
+		}
+	}
+
+	matches := ksqlStandInStatement.FindStringSubmatch(request.Ksql)
+	if matches == nil {
+		s.badStatement(w, "line 1:1: mismatched input '"+strings.Fields(request.Ksql)[0]+"' expecting {<EOF>, 'CREATE', 'DESCRIBE', 'DROP'}")
+		return
+	}
+	kind, name := strings.ToUpper(matches[1]+matches[3]), strings.ToUpper(matches[2]+matches[4])
+	source := s.sources[name]
+	success := []KsqlEntity{{Type: "currentStatus"}}
+	success[0].CommandStatus.Status = "SUCCESS"
+
+	switch kind {
+	case "CREATE STREAM", "CREATE TABLE":
+		s.sources[name] = &ksqlStandInSource{Type: strings.TrimPrefix(kind, "CREATE ")}
+		s.reply(w, http.StatusOK, success)
+	case "DESCRIBE":
+		if source == nil {
+			s.badStatement(w, "Could not find STREAM/TABLE '"+name+"' in the Metastore")
+			return
+		}
+		description := KsqlEntity{Type: "sourceDescription"}
+		description.SourceDescription.Name = name
+		description.SourceDescription.Type = source.Type
+		description.SourceDescription.Topic = strings.ToLower(name)
+		for _, query := range source.WriteQueries {
+			description.SourceDescription.WriteQueries = append(description.SourceDescription.WriteQueries, KsqlQuery{Id: query})
+		}
+		for _, query := range source.ReadQueries {
+			description.SourceDescription.ReadQueries = append(description.SourceDescription.ReadQueries, KsqlQuery{Id: query})
+		}
+		s.reply(w, http.StatusOK, []KsqlEntity{description})
+	case "DROP STREAM", "DROP TABLE":
+		if source == nil {
+			s.badStatement(w, "Source "+name+" does not exist.")
+			return
+		}
+		if len(source.ReadQueries) > 0 || len(source.WriteQueries) > 0 {
+			s.badStatement(w, "Cannot drop "+name+".\nThe following queries read from this source: ["+strings.Join(source.ReadQueries, ", ")+"].\nThe following queries write into this source: ["+strings.Join(source.WriteQueries, ", ")+"].\nYou need to terminate them before dropping "+name+".")
+			return
+		}
+		delete(s.sources, name)
+		s.reply(w, http.StatusOK, success)
+	case "TERMINATE":
+		for _, other := range s.sources {
+			other.WriteQueries = removeString(other.WriteQueries, name)
+			other.ReadQueries = removeString(other.ReadQueries, name)
+		}
+		s.reply(w, http.StatusOK, success)
+	}
+}
+
+func removeString(values []string, value string) []string {
+	var result []string
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func testKsqlStatement(t *testing.T, statement string) (*ksqlStandIn, *schema.ResourceData) {
+	standIn := &ksqlStandIn{sources: map[string]*ksqlStandInSource{}, failures: map[string]int{}}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	d := schema.TestResourceDataRaw(t, resourceKsqlStatement().Schema, map[string]interface{}{
+		"rest_endpoint": server.URL,
+		"api_key":       "key",
+		"api_secret":    "secret",
+		"statement":     statement,
+	})
+	return standIn, d
+}
+
+func TestKsqlStatementLifecycle(t *testing.T) {
+	standIn, d := testKsqlStatement(t, "CREATE STREAM payments (id VARCHAR) WITH (kafka_topic='payments', value_format='JSON')")
+
+	if err := resourceKsqlStatementCreate(d, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "PAYMENTS" || d.Get("source_type").(string) != "STREAM" || d.Get("topic").(string) != "payments" {
+		t.Errorf("unexpected state after create: id=%s type=%s topic=%s", d.Id(), d.Get("source_type"), d.Get("topic"))
+	}
+
+	// A persistent query writing to the stream is terminated before the drop
+	standIn.sources["PAYMENTS"].WriteQueries = []string{"CSAS_PAYMENTS_1"}
+	if err := resourceKsqlStatementDelete(d, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" || len(standIn.sources) != 0 {
+		t.Errorf("expected the stream to be dropped, sources left: %v", standIn.sources)
+	}
+	last := standIn.statements[len(standIn.statements)-2:]
+	if last[0] != "TERMINATE CSAS_PAYMENTS_1;" || last[1] != "DROP STREAM PAYMENTS;" {
+		t.Errorf("unexpected statements: %v", standIn.statements)
+	}
+}
+
+func TestKsqlStatementReadDroppedOutsideTerraform(t *testing.T) {
+	standIn, d := testKsqlStatement(t, "CREATE TABLE totals AS SELECT id, COUNT(*) FROM payments GROUP BY id")
+	if err := resourceKsqlStatementCreate(d, nil); err != nil {
+		t.Fatal(err)
+	}
+	delete(standIn.sources, "TOTALS")
+
+	if err := resourceKsqlStatementRead(d, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected a dropped table to be removed from state")
+	}
+}
+
+func TestKsqlStatementReadError(t *testing.T) {
+	standIn, d := testKsqlStatement(t, "CREATE STREAM payments (id VARCHAR) WITH (kafka_topic='payments', value_format='JSON')")
+	if err := resourceKsqlStatementCreate(d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other bad statement errors share the 40001 code, they must not drop the resource from state
+	d.SetId("PAYMENTS WITH")
+	if err := resourceKsqlStatementRead(d, nil); err == nil {
+		t.Errorf("expected an error describing an invalid source name")
+	}
+	if d.Id() == "" {
+		t.Errorf("expected the resource to stay in state")
+	}
+
+	d.SetId("PAYMENTS")
+	standIn.failures["DESCRIBE"] = http.StatusUnauthorized
+	if err := resourceKsqlStatementRead(d, nil); err == nil || d.Id() == "" {
+		t.Errorf("expected an authorization error to be returned and the resource kept")
+	}
+}
+
+func TestKsqlStatementDropFailure(t *testing.T) {
+	standIn, d := testKsqlStatement(t, "CREATE STREAM payments (id VARCHAR) WITH (kafka_topic='payments', value_format='JSON')")
+	if err := resourceKsqlStatementCreate(d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queries reading from the stream are not managed here, the drop fails and must be reported
+	standIn.sources["PAYMENTS"].ReadQueries = []string{"CSAS_LARGE_PAYMENTS_3"}
+	err := resourceKsqlStatementDelete(d, nil)
+	if err == nil || !strings.Contains(err.Error(), "Cannot drop PAYMENTS") {
+		t.Errorf("expected the drop failure to be returned, got %v", err)
+	}
+	if _, exists := standIn.sources["PAYMENTS"]; !exists || d.Id() == "" {
+		t.Errorf("expected the stream and the resource to remain")
+	}
+}
+
+func TestKsqlStatementNotRetried(t *testing.T) {
+	standIn, d := testKsqlStatement(t, "CREATE STREAM payments (id VARCHAR) WITH (kafka_topic='payments', value_format='JSON')")
+	standIn.failures["CREATE"] = http.StatusServiceUnavailable
+
+	if err := resourceKsqlStatementCreate(d, nil); err == nil {
+		t.Fatal("expected the create to fail")
+	}
+	if len(standIn.statements) != 1 {
+		t.Errorf("expected the statement to be sent once, sent %d times", len(standIn.statements))
+	}
+}
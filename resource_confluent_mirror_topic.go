@@ -0,0 +1,324 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"strings"
+	"time"
+)
+
+// mirrorTopicActions maps the managed state to the REST action and the mirror status it ends in
+var mirrorTopicActions = map[string][]string{
+	"mirroring":   {"resume", "ACTIVE"},
+	"paused":      {"pause", "PAUSED"},
+	"promoted":    {"promote", "STOPPED"},
+	"failed-over": {"failover", "STOPPED"},
+}
+
+func resourceMirrorTopic() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMirrorTopicCreate,
+		Read:   resourceMirrorTopicRead,
+		Update: resourceMirrorTopicUpdate,
+		Delete: resourceMirrorTopicDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceMirrorTopicImport,
+		},
+		CustomizeDiff: resourceMirrorTopicCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Computed: true,
+				Optional: true,
+			},
+			"cluster_id": &schema.Schema{
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Destination cluster of the cluster link",
+			},
+			"link_name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"source_topic": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"destination_api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "API Key of the destination cluster, the provider credentials are used when not set",
+			},
+			"destination_api_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "mirroring",
+				ValidateFunc: validation.StringInSlice([]string{"mirroring", "paused", "promoted", "failed-over"}, false),
+				Description:  "mirroring, paused, promoted or failed-over, promoted and failed-over are final",
+			},
+			"delete_promoted_topic": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the topic on destroy once promoted or failed over, it is only removed from the state otherwise",
+			},
+			"mirror_topic": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mirror_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func mirrorTopicStopped(state string) bool {
+	return state == "promoted" || state == "failed-over"
+}
+
+// resourceMirrorTopicCustomizeDiff refuses to plan a stopped mirror back to mirroring.
+// Moving between promoted and failed-over is allowed, both leave the same stopped topic.
+func resourceMirrorTopicCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("state") {
+		return nil
+	}
+	oldState, newState := d.GetChange("state")
+	if mirrorTopicStopped(oldState.(string)) && !mirrorTopicStopped(newState.(string)) {
+		return errors.New("Mirror topic " + d.Get("source_topic").(string) + " is " + oldState.(string) + " and can no longer be " + newState.(string) + ", recreate it to mirror again")
+	}
+	return nil
+}
+
+func mirrorTopicCluster(d *schema.ResourceData, config *Config) (*Cluster, error) {
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	return config.getClusterPerAccount(accountId.(string), d.Get("cluster_id").(string))
+}
+
+func mirrorTopicCredentials(d *schema.ResourceData) ClusterLinkCredentials {
+	return ClusterLinkCredentials{
+		ApiKey:    d.Get("destination_api_key").(string),
+		ApiSecret: d.Get("destination_api_secret").(string),
+	}
+}
+
+func waitForMirrorTopic(config *Config, cluster Cluster, credentials ClusterLinkCredentials, linkName string, topic string, target string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING_MIRROR", "ACTIVE", "PENDING_PAUSED", "PAUSED", "PENDING_STOPPED"},
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			mirror, err := config.getMirrorTopic(cluster, credentials, linkName, topic)
+			if err != nil {
+				return nil, "", err
+			}
+			if mirror == nil {
+				return nil, "", errors.New("Unable to find mirror topic " + topic + " on cluster link " + linkName)
+			}
+			if mirror.MirrorStatus == "FAILED" {
+				return nil, "FAILED", errors.New("Mirror topic " + topic + " failed")
+			}
+			return mirror, mirror.MirrorStatus, nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// applyMirrorTopicState runs the action leading to state and waits for the mirror to reach it
+func applyMirrorTopicState(d *schema.ResourceData, config *Config, cluster Cluster, state string, timeout time.Duration) error {
+	linkName := d.Get("link_name").(string)
+	topic := d.Get("source_topic").(string)
+	action := mirrorTopicActions[state]
+	log.Print("Running " + action[0] + " on mirror topic " + topic + " of cluster link " + linkName)
+	if err := config.alterMirrorTopic(cluster, mirrorTopicCredentials(d), linkName, topic, action[0]); err != nil {
+		return err
+	}
+	return waitForMirrorTopic(config, cluster, mirrorTopicCredentials(d), linkName, topic, action[1], timeout)
+}
+
+func resourceMirrorTopicCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	cluster, err := mirrorTopicCluster(d, config)
+	if err != nil {
+		return err
+	}
+	linkName := d.Get("link_name").(string)
+	topic := d.Get("source_topic").(string)
+	log.Print("Mirroring topic " + topic + " on cluster link " + linkName + " in cluster " + cluster.Id)
+	if err := config.createMirrorTopic(*cluster, mirrorTopicCredentials(d), linkName, topic); err != nil {
+		return err
+	}
+	d.SetId(cluster.Id + "/" + linkName + "/" + topic)
+	d.Set("account_id", cluster.AccountId)
+
+	if err := waitForMirrorTopic(config, *cluster, mirrorTopicCredentials(d), linkName, topic, "ACTIVE", d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+	if state := d.Get("state").(string); state != "mirroring" {
+		if err := applyMirrorTopicState(d, config, *cluster, state, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+	return resourceMirrorTopicRead(d, m)
+}
+
+func resourceMirrorTopicRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	cluster, err := mirrorTopicCluster(d, config)
+	if err != nil {
+		return err
+	}
+	linkName := d.Get("link_name").(string)
+	topic := d.Get("source_topic").(string)
+	mirror, err := config.getMirrorTopic(*cluster, mirrorTopicCredentials(d), linkName, topic)
+	if err != nil {
+		return err
+	}
+	if mirror == nil && mirrorTopicStopped(d.Get("state").(string)) {
+		// A promoted or failed over topic can leave the mirrors of the link while the topic remains
+		mirrorTopic := d.Get("mirror_topic").(string)
+		if mirrorTopic == "" {
+			mirrorTopic = topic
+		}
+		exists, err := config.mirroredTopicExists(*cluster, mirrorTopicCredentials(d), mirrorTopic)
+		if err != nil {
+			return err
+		}
+		if exists {
+			d.Set("mirror_status", "STOPPED")
+			return nil
+		}
+	}
+	if mirror == nil {
+		log.Print("Unable to find mirror topic " + topic + " on cluster link " + linkName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("mirror_topic", mirror.MirrorTopicName)
+	d.Set("mirror_status", mirror.MirrorStatus)
+	switch mirror.MirrorStatus {
+	case "PENDING_MIRROR", "ACTIVE":
+		d.Set("state", "mirroring")
+	case "PENDING_PAUSED", "PAUSED":
+		d.Set("state", "paused")
+	case "PENDING_STOPPED", "STOPPED":
+		// The API does not tell a promotion from a failover apart
+		if state := d.Get("state").(string); state != "promoted" && state != "failed-over" {
+			d.Set("state", "promoted")
+		}
+	}
+
+	return nil
+}
+
+func resourceMirrorTopicUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	oldState, newState := d.GetChange("state")
+	if d.HasChange("state") && !(mirrorTopicStopped(oldState.(string)) && mirrorTopicStopped(newState.(string))) {
+		cluster, err := mirrorTopicCluster(d, config)
+		if err != nil {
+			return err
+		}
+		if err := applyMirrorTopicState(d, config, *cluster, d.Get("state").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+	return resourceMirrorTopicRead(d, m)
+}
+
+// resourceMirrorTopicDelete deletes the topic from the destination cluster.
+// Once promoted or failed over the topic serves producers, so it is kept unless delete_promoted_topic is set.
+func resourceMirrorTopicDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	if mirrorTopicStopped(d.Get("state").(string)) && !d.Get("delete_promoted_topic").(bool) {
+		log.Print("Keeping " + d.Get("state").(string) + " topic " + d.Get("mirror_topic").(string) + ", only removing it from the state")
+		d.SetId("")
+		return nil
+	}
+
+	cluster, err := mirrorTopicCluster(d, config)
+	if err != nil {
+		return err
+	}
+	topic := d.Get("mirror_topic").(string)
+	if topic == "" {
+		topic = d.Get("source_topic").(string)
+	}
+	log.Print("Deleting mirror topic " + topic + " in cluster " + cluster.Id)
+	if err := config.deleteMirrorTopic(*cluster, mirrorTopicCredentials(d), topic); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceMirrorTopicImport expects <cluster_id>/<link_name>/<source_topic>
+func resourceMirrorTopicImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return nil, err
+	}
+
+	importId := d.Id()
+	tokens := strings.Split(importId, "/")
+	if len(tokens) != 3 {
+		return nil, errors.New("Invalid mirror topic ID " + importId + ", expecting <cluster_id>/<link_name>/<source_topic>")
+	}
+
+	for _, account := range config.Me.Accounts {
+		cluster, err := config.getClusterPerAccount(account.Id, tokens[0])
+		if err != nil || cluster == nil {
+			continue
+		}
+		log.Print("Importing mirror topic " + tokens[2] + " of cluster link " + tokens[1] + " from account " + account.Id)
+		d.Set("account_id", account.Id)
+		d.Set("cluster_id", cluster.Id)
+		d.Set("link_name", tokens[1])
+		d.Set("source_topic", tokens[2])
+		d.Set("state", "mirroring")
+		return []*schema.ResourceData{d}, nil
+	}
+	return nil, errors.New("Unable to find cluster " + tokens[0])
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// schemaRegistryCredentials are shared by every resource talking to the Schema Registry REST API.
+// They default to the provider configuration so that imported resources can be read.
+func schemaRegistryCredentials() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"schema_registry_endpoint": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Schema Registry endpoint. If not set, using provider configuration",
+		},
+		"schema_registry_api_key": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Schema Registry API key. If not set, using provider configuration",
+		},
+		"schema_registry_api_secret": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Schema Registry API secret. If not set, using provider configuration",
+		},
+	}
+}
+
+// withSchemaRegistryCredentials adds the Schema Registry credentials to a resource schema
+func withSchemaRegistryCredentials(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for k, v := range schemaRegistryCredentials() {
+		s[k] = v
+	}
+	return s
+}
+
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type resourceGetter interface {
+	Get(key string) interface{}
+}
+
+func schemaRegistryClient(d resourceGetter, config *Config) (*SchemaRegistryClient, error) {
+	client := SchemaRegistryClient{
+		Endpoint:  config.SchemaRegistryEndpoint,
+		ApiKey:    config.SchemaRegistryApiKey,
+		ApiSecret: config.SchemaRegistryApiSecret,
+	}
+	if endpoint := d.Get("schema_registry_endpoint").(string); endpoint != "" {
+		client.Endpoint = endpoint
+	}
+	if apiKey := d.Get("schema_registry_api_key").(string); apiKey != "" {
+		client.ApiKey = apiKey
+	}
+	if apiSecret := d.Get("schema_registry_api_secret").(string); apiSecret != "" {
+		client.ApiSecret = apiSecret
+	}
+	if client.Endpoint == "" || client.ApiKey == "" {
+		return nil, errors.New("Schema Registry endpoint and API key must be set on the resource or on the provider")
+	}
+	return &client, nil
+}
+
+func resourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSchemaCreate,
+		Read:   resourceSchemaRead,
+		Update: resourceSchemaUpdate,
+		Delete: resourceSchemaDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSchemaImport,
+		},
+
+		CustomizeDiff: resourceSchemaCustomizeDiff,
+
+		Schema: withSchemaRegistryCredentials(map[string]*schema.Schema{
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "AVRO",
+				ValidateFunc: validation.StringInSlice([]string{"AVRO", "PROTOBUF", "JSON"}, false),
+				Description:  "AVRO, PROTOBUF or JSON",
+			},
+			"schema": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: schemaDiffSuppress,
+				Description:      "Schema definition",
+			},
+			"reference": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Schemas imported by this schema",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name used to import the schema (type name for Avro, file name for Protobuf, URL for JSON)",
+						},
+						"subject": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"hard_delete": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Permanently delete the subject on destroy instead of a soft delete",
+			},
+			"schema_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"version": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version managed by this resource, the one registered last or imported",
+			},
+			"latest_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"fingerprint": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the schema as stored by the registry",
+			},
+		}),
+	}
+}
+
+func schemaFingerprint(definition string) string {
+	sum := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeSchema returns a form of the definition that ignores formatting: compact JSON for Avro and JSON schemas,
+// whitespace collapsed for Protobuf. The registry stores schemas in its own canonical form.
+func normalizeSchema(format string, definition string) string {
+	if format != "PROTOBUF" {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(definition), &parsed); err == nil {
+			if normalized, err := json.Marshal(parsed); err == nil {
+				return string(normalized)
+			}
+		}
+	}
+	return strings.Join(strings.Fields(definition), " ")
+}
+
+func schemaDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeSchema(d.Get("format").(string), old) == normalizeSchema(d.Get("format").(string), new)
+}
+
+func getSchemaReferences(d resourceGetter) []SchemaReference {
+	var references []SchemaReference
+	for _, reference := range d.Get("reference").([]interface{}) {
+		r := reference.(map[string]interface{})
+		references = append(references, SchemaReference{
+			Name:    r["name"].(string),
+			Subject: r["subject"].(string),
+			Version: r["version"].(int),
+		})
+	}
+	return references
+}
+
+func flattenSchemaReferences(references []SchemaReference) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, reference := range references {
+		result = append(result, map[string]interface{}{
+			"name":    reference.Name,
+			"subject": reference.Subject,
+			"version": reference.Version,
+		})
+	}
+	return result
+}
+
+// registerSchema registers the configured schema as a new version of the subject and records it in state
+func registerSchema(d *schema.ResourceData, client *SchemaRegistryClient) error {
+	subject := d.Get("subject").(string)
+	format := d.Get("format").(string)
+	registryFormat := format
+	if format == "AVRO" {
+		registryFormat = ""
+	}
+	definition := SchemaRegistrySchema{
+		SchemaType: registryFormat,
+		Schema:     d.Get("schema").(string),
+		References: getSchemaReferences(d),
+	}
+	log.Print("Registering " + format + " schema for subject " + subject)
+	if _, err := client.registerSchema(subject, definition); err != nil {
+		return err
+	}
+
+	registered, err := client.lookupSchema(subject, definition)
+	if err != nil {
+		return err
+	}
+	if registered == nil {
+		return errors.New("Unable to find the schema just registered for subject " + subject)
+	}
+	d.Set("schema_id", registered.Id)
+	d.Set("version", registered.Version)
+	d.Set("fingerprint", schemaFingerprint(registered.Schema))
+	return nil
+}
+
+// resourceSchemaCustomizeDiff asks the registry whether a planned schema is compatible with the latest version.
+// New resources are checked too since they can register onto an existing subject, the registry answers 404 when the subject does not exist.
+func resourceSchemaCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !(d.HasChange("schema") || d.HasChange("format") || d.HasChange("reference")) {
+		return nil
+	}
+	for _, key := range []string{"subject", "schema", "format", "reference", "schema_registry_endpoint", "schema_registry_api_key", "schema_registry_api_secret"} {
+		if !d.NewValueKnown(key) {
+			log.Print("Unable to check schema compatibility during plan, " + key + " is not known yet")
+			return nil
+		}
+	}
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+
+	subject := d.Get("subject").(string)
+	format := d.Get("format").(string)
+	if format == "AVRO" {
+		format = ""
+	}
+	compatible, messages, err := client.checkCompatibility(subject, SchemaRegistrySchema{
+		SchemaType: format,
+		Schema:     d.Get("schema").(string),
+		References: getSchemaReferences(d),
+	})
+	if err != nil {
+		return err
+	}
+	if !compatible {
+		return errors.New("Schema is not compatible with the latest version of subject " + subject + ":\n" + strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+func resourceSchemaCreate(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	if err := registerSchema(d, client); err != nil {
+		return err
+	}
+	d.SetId(d.Get("subject").(string))
+	return resourceSchemaRead(d, m)
+}
+
+func resourceSchemaRead(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+
+	subject := d.Id()
+	latest, err := client.getSchema(subject, "latest")
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		log.Print("Unable to find subject " + subject)
+		d.SetId("")
+		return nil
+	}
+	// A resource that owned the latest version reports a version registered outside Terraform as drift,
+	// an imported older version stays pinned to it
+	managed := latest
+	version := d.Get("version").(int)
+	ownedLatest := version == d.Get("latest_version").(int)
+	d.Set("latest_version", latest.Version)
+	if version != 0 && version != latest.Version && !ownedLatest {
+		log.Print("Subject " + subject + " is at version " + strconv.Itoa(latest.Version) + ", reading version " + strconv.Itoa(version))
+		managed, err = client.getSchema(subject, strconv.Itoa(version))
+		if err != nil {
+			return err
+		}
+		if managed == nil {
+			// The version was deleted outside Terraform, plan registering the schema again
+			log.Print("Version " + strconv.Itoa(version) + " of subject " + subject + " no longer exists")
+			d.Set("schema", "")
+			d.Set("fingerprint", "")
+			return nil
+		}
+	}
+
+	// A different fingerprint means another version was registered outside Terraform
+	if fingerprint := schemaFingerprint(managed.Schema); fingerprint != d.Get("fingerprint").(string) {
+		log.Print("Subject " + subject + " changed outside Terraform, reading version " + strconv.Itoa(managed.Version))
+		d.Set("schema", managed.Schema)
+		d.Set("fingerprint", fingerprint)
+	}
+	d.Set("subject", managed.Subject)
+	d.Set("format", managed.Format())
+	d.Set("schema_id", managed.Id)
+	d.Set("version", managed.Version)
+	if err := d.Set("reference", flattenSchemaReferences(managed.References)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceSchemaUpdate(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	if d.HasChange("schema") || d.HasChange("format") || d.HasChange("reference") {
+		if err := registerSchema(d, client); err != nil {
+			return err
+		}
+	}
+	return resourceSchemaRead(d, m)
+}
+
+func resourceSchemaDelete(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	subject := d.Id()
+	hardDelete := d.Get("hard_delete").(bool)
+	log.Print("Deleting subject " + subject + " (permanent: " + strconv.FormatBool(hardDelete) + ")")
+	if err := client.deleteSubject(subject, hardDelete); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceSchemaImport takes <subject>/<version>, using the provider Schema Registry credentials
+func resourceSchemaImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importId := d.Id()
+	separator := strings.LastIndex(importId, "/")
+	if separator <= 0 {
+		return nil, errors.New("Invalid schema ID " + importId + ", expecting <subject>/<version>")
+	}
+	subject := importId[:separator]
+	version := importId[separator+1:]
+
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	registered, err := client.getSchema(subject, version)
+	if err != nil {
+		return nil, err
+	}
+	if registered == nil {
+		return nil, errors.New("Unable to find version " + version + " of subject " + subject)
+	}
+
+	d.SetId(subject)
+	d.Set("subject", subject)
+	d.Set("format", registered.Format())
+	d.Set("schema", registered.Schema)
+	d.Set("schema_id", registered.Id)
+	// Read follows this version, not the latest one
+	d.Set("version", registered.Version)
+	d.Set("fingerprint", schemaFingerprint(registered.Schema))
+	d.Set("hard_delete", false)
+	return []*schema.ResourceData{d}, nil
+}
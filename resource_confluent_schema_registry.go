@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+)
+
+func resourceSchemaRegistry() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSchemaRegistryCreate,
+		Read:   resourceSchemaRegistryRead,
+		Delete: resourceSchemaRegistryDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSchemaRegistryImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Computed: true,
+				Optional: true,
+			},
+			"service_provider": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"aws", "gcp", "azure"}, false),
+				Description:  "Cloud provider: aws, gcp or azure",
+			},
+			"geography": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"us", "eu", "apac"}, false),
+				Description:  "Schema Registry location: us, eu or apac",
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"modified": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSchemaRegistryCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+
+	existing, err := config.getSchemaRegistry(accountId.(string))
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.New("Schema Registry is already enabled for account " + accountId.(string) + ", import it with: terraform import <address> " + accountId.(string))
+	}
+
+	log.Print("Enabling Schema Registry for account " + accountId.(string))
+	schemaRegistry, err := config.createSchemaRegistry(accountId.(string), d.Get("service_provider").(string), d.Get("geography").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(schemaRegistry.Id)
+	d.Set("account_id", accountId.(string))
+	return resourceSchemaRegistryRead(d, m)
+}
+
+func resourceSchemaRegistryRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+
+	schemaRegistry, err := config.getSchemaRegistry(accountId.(string))
+	if err != nil {
+		return err
+	}
+	if schemaRegistry == nil || schemaRegistry.Id != d.Id() {
+		log.Print("Unable to find Schema Registry " + d.Id() + " in account " + accountId.(string))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("account_id", accountId.(string))
+	d.Set("name", schemaRegistry.Name)
+	d.Set("service_provider", schemaRegistry.ServiceProvider)
+	d.Set("geography", schemaRegistry.Location)
+	d.Set("endpoint", schemaRegistry.Endpoint)
+	d.Set("status", schemaRegistry.Status)
+	d.Set("created", schemaRegistry.Created)
+	d.Set("modified", schemaRegistry.Modified)
+
+	return nil
+}
+
+// resourceSchemaRegistryImport takes the account ID, there is at most one Schema Registry per account
+func resourceSchemaRegistryImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return nil, err
+	}
+
+	accountId := d.Id()
+	schemaRegistry, err := config.getSchemaRegistry(accountId)
+	if err != nil {
+		return nil, err
+	}
+	if schemaRegistry == nil {
+		return nil, errors.New("Schema Registry is not enabled for account " + accountId)
+	}
+
+	d.SetId(schemaRegistry.Id)
+	d.Set("account_id", accountId)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSchemaRegistryDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	if err := config.connect(); err != nil {
+		return err
+	}
+
+	accountId, accountIdSet := d.GetOk("account_id")
+	if !accountIdSet {
+		accountId = config.Me.Account.Id
+	}
+	schemaRegistry, err := config.getSchemaRegistry(accountId.(string))
+	if err != nil {
+		return err
+	}
+	if schemaRegistry == nil || schemaRegistry.Id != d.Id() {
+		// Already gone, or replaced by another Schema Registry that this resource does not manage
+		log.Print("Unable to find Schema Registry " + d.Id() + " in account " + accountId.(string) + ", nothing to delete")
+		d.SetId("")
+		return nil
+	}
+	if err := config.deleteSchemaRegistry(*schemaRegistry); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+)
+
+var compatibilityLevels = []string{
+	"BACKWARD",
+	"BACKWARD_TRANSITIVE",
+	"FORWARD",
+	"FORWARD_TRANSITIVE",
+	"FULL",
+	"FULL_TRANSITIVE",
+	"NONE",
+}
+
+var schemaRegistryModes = []string{
+	"READWRITE",
+	"READONLY",
+	"IMPORT",
+}
+
+func resourceSchemaRegistryConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSchemaRegistryConfigUpdate,
+		Read:   resourceSchemaRegistryConfigRead,
+		Update: resourceSchemaRegistryConfigUpdate,
+		Delete: resourceSchemaRegistryConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: withSchemaRegistryCredentials(map[string]*schema.Schema{
+			"compatibility_level": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(compatibilityLevels, false),
+			},
+			"mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(schemaRegistryModes, false),
+			},
+		}),
+	}
+}
+
+func resourceSchemaRegistryConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	if level, ok := d.GetOk("compatibility_level"); ok && d.HasChange("compatibility_level") {
+		log.Print("Setting global compatibility level to " + level.(string))
+		if err := client.setCompatibility("", level.(string)); err != nil {
+			return err
+		}
+	}
+	if mode, ok := d.GetOk("mode"); ok && d.HasChange("mode") {
+		log.Print("Setting global mode to " + mode.(string))
+		if err := client.setMode("", mode.(string)); err != nil {
+			return err
+		}
+	}
+	d.SetId("global")
+	return resourceSchemaRegistryConfigRead(d, m)
+}
+
+func resourceSchemaRegistryConfigRead(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	level, err := client.getCompatibility("")
+	if err != nil {
+		return err
+	}
+	mode, err := client.getMode("")
+	if err != nil {
+		return err
+	}
+	d.Set("compatibility_level", level)
+	d.Set("mode", mode)
+	return nil
+}
+
+// resourceSchemaRegistryConfigDelete leaves the registry settings as they are, there is no way to unset them
+func resourceSchemaRegistryConfigDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Global Schema Registry config removed from Terraform, leaving it unchanged")
+	d.SetId("")
+	return nil
+}
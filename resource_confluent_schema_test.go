@@ -0,0 +1,89 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeSchema(t *testing.T) {
+	cases := []struct {
+		format     string
+		a          string
+		b          string
+		equivalent bool
+	}{
+		{
+			"AVRO",
+			`{"type":"record","name":"Payment","fields":[{"name":"id","type":"string"}]}`,
+			"{\n  \"type\": \"record\",\n  \"name\": \"Payment\",\n  \"fields\": [\n    { \"name\": \"id\", \"type\": \"string\" }\n  ]\n}\n",
+			true,
+		},
+		{
+			"AVRO",
+			`{"type":"record","name":"Payment","fields":[{"name":"id","type":"string"},{"name":"amount","type":"double"}]}`,
+			`{"type":"record","name":"Payment","fields":[{"name":"amount","type":"double"},{"name":"id","type":"string"}]}`,
+			false,
+		},
+		{
+			"JSON",
+			`{"type":"object","properties":{"id":{"type":"string"}}}`,
+			`{ "properties": { "id": { "type": "string" } }, "type": "object" }`,
+			true,
+		},
+		{
+			"AVRO",
+			`"string"`,
+			` "string"`,
+			true,
+		},
+		{
+			"PROTOBUF",
+			"syntax = \"proto3\";\nmessage Payment {\n  string id = 1;\n}\n",
+			"syntax = \"proto3\"; message Payment { string id = 1; }",
+			true,
+		},
+		{
+			"PROTOBUF",
+			"syntax = \"proto3\";\nmessage Payment {\n  string id = 1;\n}\n",
+			"syntax = \"proto3\";\nmessage Payment {\n  string id = 2;\n}\n",
+			false,
+		},
+	}
+	for _, c := range cases {
+		if equivalent := normalizeSchema(c.format, c.a) == normalizeSchema(c.format, c.b); equivalent != c.equivalent {
+			t.Errorf("%s schemas %q and %q: equivalent = %v, expected %v", c.format, c.a, c.b, equivalent, c.equivalent)
+		}
+	}
+}
+
+func TestSchemaCustomizeDiffOnCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/compatibility/subjects/payments-value/versions/latest":
+			w.Write([]byte(`{"is_compatible":false,"messages":["reader field amount has no default"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	config := &Config{SchemaRegistryEndpoint: server.URL, SchemaRegistryApiKey: "KEY", SchemaRegistryApiSecret: "SECRET"}
+
+	plan := func(subject string) error {
+		_, err := resourceSchema().Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"subject": subject,
+			"format":  "AVRO",
+			"schema":  `{"type":"record","name":"Payment","fields":[{"name":"amount","type":"int"}]}`,
+		}), config)
+		return err
+	}
+	if err := plan("payments-value"); err == nil || !strings.Contains(err.Error(), "reader field amount has no default") {
+		t.Errorf("expected an incompatible schema on an existing subject to fail the plan, got %v", err)
+	}
+	if err := plan("refunds-value"); err != nil {
+		t.Errorf("expected a new subject to plan, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"log"
+)
+
+func resourceSubjectConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSubjectConfigCreate,
+		Read:   resourceSubjectConfigRead,
+		Update: resourceSubjectConfigUpdate,
+		Delete: resourceSubjectConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: withSchemaRegistryCredentials(map[string]*schema.Schema{
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"compatibility_level": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(compatibilityLevels, false),
+				Description:  "If not set, the subject uses the global compatibility level",
+			},
+			"mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(schemaRegistryModes, false),
+				Description:  "If not set, the subject uses the global mode",
+			},
+		}),
+	}
+}
+
+func resourceSubjectConfigCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("subject").(string))
+	return resourceSubjectConfigUpdate(d, m)
+}
+
+func resourceSubjectConfigRead(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	subject := d.Id()
+	level, err := client.getCompatibility(subject)
+	if err != nil {
+		return err
+	}
+	mode, err := client.getMode(subject)
+	if err != nil {
+		return err
+	}
+	d.Set("subject", subject)
+	d.Set("compatibility_level", level)
+	d.Set("mode", mode)
+	return nil
+}
+
+func resourceSubjectConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	subject := d.Id()
+
+	if d.HasChange("compatibility_level") || d.IsNewResource() {
+		if level, ok := d.GetOk("compatibility_level"); ok {
+			log.Print("Setting compatibility level of subject " + subject + " to " + level.(string))
+			err = client.setCompatibility(subject, level.(string))
+		} else {
+			log.Print("Resetting compatibility level of subject " + subject)
+			err = client.deleteCompatibility(subject)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if d.HasChange("mode") || d.IsNewResource() {
+		if mode, ok := d.GetOk("mode"); ok {
+			log.Print("Setting mode of subject " + subject + " to " + mode.(string))
+			err = client.setMode(subject, mode.(string))
+		} else {
+			log.Print("Resetting mode of subject " + subject)
+			err = client.deleteMode(subject)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return resourceSubjectConfigRead(d, m)
+}
+
+func resourceSubjectConfigDelete(d *schema.ResourceData, m interface{}) error {
+	client, err := schemaRegistryClient(d, m.(*Config))
+	if err != nil {
+		return err
+	}
+	subject := d.Id()
+	log.Print("Resetting compatibility level and mode of subject " + subject)
+	if err := client.deleteCompatibility(subject); err != nil {
+		return err
+	}
+	if err := client.deleteMode(subject); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// A subject without its own mode or compatibility level must read back as unset, not as the global value
+func TestSubjectSettingsWithoutGlobalDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mode", "/config":
+			w.Write([]byte(`{"mode":"READWRITE","compatibilityLevel":"BACKWARD"}`))
+		case "/mode/payments-value", "/config/payments-value":
+			if r.URL.Query().Get("defaultToGlobal") == "false" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error_code":40409,"message":"Subject 'payments-value' does not have subject-level mode configured"}`))
+				return
+			}
+			w.Write([]byte(`{"mode":"READWRITE","compatibilityLevel":"BACKWARD"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := SchemaRegistryClient{Endpoint: server.URL, ApiKey: "key", ApiSecret: "secret"}
+
+	if mode, err := client.getMode("payments-value"); err != nil || mode != "" {
+		t.Errorf("expected no subject mode, got %q (%v)", mode, err)
+	}
+	if level, err := client.getCompatibility("payments-value"); err != nil || level != "" {
+		t.Errorf("expected no subject compatibility level, got %q (%v)", level, err)
+	}
+	if mode, err := client.getMode(""); err != nil || mode != "READWRITE" {
+		t.Errorf("expected the global mode, got %q (%v)", mode, err)
+	}
+}
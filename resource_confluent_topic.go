@@ -1,7 +1,10 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"log"
 	"strconv"
 	"strings"
@@ -14,7 +17,7 @@ func resourceTopic() *schema.Resource {
 		Update: resourceTopicUpdate,
 		Delete: resourceTopicDelete,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withSchemaRegistryCredentials(map[string]*schema.Schema{
 			"account_id": &schema.Schema{
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -90,10 +93,215 @@ func resourceTopic() *schema.Resource {
 				Optional: true,
 				Default:  "9223372036854775807",
 			},
+			"key_schema": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        topicSchemaResource(),
+				Description: "Schema registered for the message keys",
+			},
+			"value_schema": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        topicSchemaResource(),
+				Description: "Schema registered for the message values",
+			},
+		}),
+	}
+}
+
+func topicSchemaResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "AVRO",
+				ValidateFunc: validation.StringInSlice([]string{"AVRO", "PROTOBUF", "JSON"}, false),
+			},
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"subject_name_strategy": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "TopicName",
+				ValidateFunc: validation.StringInSlice([]string{"TopicName", "RecordName", "TopicRecordName"}, false),
+				Description:  "TopicName, RecordName or TopicRecordName",
+			},
+			"record_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Fully qualified record name used by the RecordName strategies. Read from the schema for Avro",
+			},
+			"validation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable broker-side schema validation (Dedicated clusters only)",
+			},
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"schema_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// avroRecordName returns the fully qualified name of an Avro record schema
+func avroRecordName(definition string) (string, error) {
+	var record struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+	if err := json.Unmarshal([]byte(definition), &record); err != nil {
+		return "", err
+	}
+	if record.Name == "" {
+		return "", errors.New("Avro schema has no name")
+	}
+	if record.Namespace == "" || strings.Contains(record.Name, ".") {
+		return record.Name, nil
+	}
+	return record.Namespace + "." + record.Name, nil
+}
+
+// topicSchemaSubject derives the subject of a key or value schema from its naming strategy
+func topicSchemaSubject(topicName string, kind string, topicSchema map[string]interface{}) (string, error) {
+	strategy := topicSchema["subject_name_strategy"].(string)
+	if strategy == "TopicName" {
+		return topicName + "-" + kind, nil
+	}
+	recordName := topicSchema["record_name"].(string)
+	if recordName == "" && topicSchema["format"].(string) == "AVRO" {
+		name, err := avroRecordName(topicSchema["schema"].(string))
+		if err != nil {
+			return "", errors.New("Unable to read the record name of the " + kind + " schema: " + err.Error())
+		}
+		recordName = name
+	}
+	if recordName == "" {
+		return "", errors.New("record_name must be set on the " + kind + " schema to use the " + strategy + " strategy")
+	}
+	if strategy == "RecordName" {
+		return recordName, nil
+	}
+	return topicName + "-" + recordName, nil
+}
+
+func getTopicSchema(d *schema.ResourceData, kind string) map[string]interface{} {
+	return topicSchemaBlock(d.Get(kind + "_schema"))
+}
+
+func topicSchemaBlock(topicSchemas interface{}) map[string]interface{} {
+	if len(topicSchemas.([]interface{})) == 0 || topicSchemas.([]interface{})[0] == nil {
+		return nil
+	}
+	return topicSchemas.([]interface{})[0].(map[string]interface{})
+}
+
+// topicSchemaValidated tells whether the block turns on broker-side validation, the topic configs are only set then
+func topicSchemaValidated(topicSchema map[string]interface{}) bool {
+	return topicSchema != nil && topicSchema["validation"].(bool)
+}
+
+func topicSchemaConfigNames(kind string) []string {
+	return []string{"confluent." + kind + ".schema.validation", "confluent." + kind + ".subject.name.strategy"}
+}
+
+// topicSchemaConfigsToReset lists the validation configs set by the prior state that the new one no longer sets
+func topicSchemaConfigsToReset(d *schema.ResourceData) []string {
+	var names []string
+	for _, kind := range []string{"key", "value"} {
+		oldSchema, newSchema := d.GetChange(kind + "_schema")
+		if topicSchemaValidated(topicSchemaBlock(oldSchema)) && !topicSchemaValidated(topicSchemaBlock(newSchema)) {
+			names = append(names, topicSchemaConfigNames(kind)...)
+		}
+	}
+	return names
+}
+
+// registerTopicSchemas registers the key and value schemas under the subjects derived from their strategy
+func registerTopicSchemas(d *schema.ResourceData, m interface{}) error {
+	for _, kind := range []string{"key", "value"} {
+		topicSchema := getTopicSchema(d, kind)
+		if topicSchema == nil || !(d.IsNewResource() || d.HasChange(kind+"_schema")) {
+			continue
+		}
+		client, err := schemaRegistryClient(d, m.(*Config))
+		if err != nil {
+			return err
+		}
+		subject, err := topicSchemaSubject(d.Get("name").(string), kind, topicSchema)
+		if err != nil {
+			return err
+		}
+		format := topicSchema["format"].(string)
+		definition := SchemaRegistrySchema{
+			Schema: topicSchema["schema"].(string),
+		}
+		if format != "AVRO" {
+			definition.SchemaType = format
+		}
+		log.Print("Registering " + kind + " schema of topic " + d.Get("name").(string) + " under subject " + subject)
+		if _, err := client.registerSchema(subject, definition); err != nil {
+			return err
+		}
+		registered, err := client.lookupSchema(subject, definition)
+		if err != nil {
+			return err
+		}
+		if registered == nil {
+			return errors.New("Unable to find the " + kind + " schema just registered for subject " + subject)
+		}
+		topicSchema["subject"] = subject
+		topicSchema["schema_id"] = registered.Id
+		topicSchema["version"] = registered.Version
+		if err := d.Set(kind+"_schema", []interface{}{topicSchema}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readTopicSchemas clears the schema body when its subject disappeared so that the next apply registers it again
+func readTopicSchemas(d *schema.ResourceData, m interface{}) error {
+	for _, kind := range []string{"key", "value"} {
+		topicSchema := getTopicSchema(d, kind)
+		if topicSchema == nil || topicSchema["subject"].(string) == "" {
+			continue
+		}
+		client, err := schemaRegistryClient(d, m.(*Config))
+		if err != nil {
+			return err
+		}
+		registered, err := client.getSchema(topicSchema["subject"].(string), strconv.Itoa(topicSchema["version"].(int)))
+		if err != nil {
+			return err
+		}
+		if registered == nil {
+			log.Print("Subject " + topicSchema["subject"].(string) + " no longer exists")
+			topicSchema["schema"] = ""
+			topicSchema["schema_id"] = 0
+			topicSchema["version"] = 0
+			if err := d.Set(kind+"_schema", []interface{}{topicSchema}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func getParams(d *schema.ResourceData) []KafkaTopicConfig {
 	params := []KafkaTopicConfig{
 		{
@@ -137,6 +345,21 @@ func getParams(d *schema.ResourceData) []KafkaTopicConfig {
 			Value: d.Get("message_timestamp_difference_max_ms").(string),
 		},
 	}
+	for _, kind := range []string{"key", "value"} {
+		if topicSchema := getTopicSchema(d, kind); topicSchemaValidated(topicSchema) {
+			names := topicSchemaConfigNames(kind)
+			params = append(params,
+				KafkaTopicConfig{
+					Name:  names[0],
+					Value: "true",
+				},
+				KafkaTopicConfig{
+					Name:  names[1],
+					Value: "io.confluent.kafka.serializers.subject." + topicSchema["subject_name_strategy"].(string) + "Strategy",
+				},
+			)
+		}
+	}
 	return params
 }
 
@@ -153,7 +376,7 @@ func resourceTopicCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	clusterId := d.Get("cluster_id").(string)
 
-	log.Printf("Creating topic " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
+	log.Print("Creating topic " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
 
 	params := getParams(d)
 
@@ -168,6 +391,9 @@ func resourceTopicCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(accountId.(string) + "-" + clusterId + "-" + name)
+	if err := registerTopicSchemas(d, m); err != nil {
+		return err
+	}
 	return resourceTopicRead(d, m)
 }
 
@@ -183,7 +409,7 @@ func resourceTopicRead(d *schema.ResourceData, m interface{}) error {
 	}
 	clusterId := d.Get("cluster_id")
 
-	log.Printf("Reading topic: " + d.Get("name").(string) + " for account " + accountId.(string) + " and cluster " + clusterId.(string))
+	log.Print("Reading topic: " + d.Get("name").(string) + " for account " + accountId.(string) + " and cluster " + clusterId.(string))
 
 	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId.(string))
 	if err != nil {
@@ -207,7 +433,7 @@ func resourceTopicRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("cluster_name", cluster.Name)
 	d.Set("num_partitions", len(topic.Partitions))
 
-	return nil
+	return readTopicSchemas(d, m)
 }
 
 func resourceTopicUpdate(d *schema.ResourceData, m interface{}) error {
@@ -223,7 +449,7 @@ func resourceTopicUpdate(d *schema.ResourceData, m interface{}) error {
 	clusterId := d.Get("cluster_id").(string)
 	name := d.Get("name").(string)
 
-	log.Printf("Updating topic " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
+	log.Print("Updating topic " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
 
 	params := getParams(d)
 
@@ -236,11 +462,20 @@ func resourceTopicUpdate(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return nil
 	}
+	if names := topicSchemaConfigsToReset(d); len(names) > 0 {
+		log.Print("Resetting " + strings.Join(names, ", ") + " on topic " + name)
+		if err := config.resetTopicConfigs(*cluster, name, names); err != nil {
+			return err
+		}
+	}
+	if err := registerTopicSchemas(d, m); err != nil {
+		return err
+	}
 	return resourceTopicRead(d, m)
 }
 
 func resourceTopicDelete(d *schema.ResourceData, m interface{}) error {
-	log.Printf("Deleting topic: " + d.Get("name").(string))
+	log.Print("Deleting topic: " + d.Get("name").(string))
 	config := m.(*Config)
 	if err := config.connect(); err != nil {
 		return err
@@ -252,7 +487,7 @@ func resourceTopicDelete(d *schema.ResourceData, m interface{}) error {
 	}
 	clusterId := d.Get("cluster_id").(string)
 	name := d.Get("name").(string)
-	log.Printf("Deleting topic " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
+	log.Print("Deleting topic " + name + " in account " + accountId.(string) + " for cluster " + clusterId)
 
 	cluster, err := config.getClusterPerAccount(accountId.(string), clusterId)
 	if err != nil {
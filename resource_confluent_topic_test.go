@@ -0,0 +1,73 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"testing"
+)
+
+func testTopicConfig(valueSchema map[string]interface{}) map[string]interface{} {
+	raw := map[string]interface{}{
+		"cluster_id": "lkc-1",
+		"name":       "payments",
+	}
+	if valueSchema != nil {
+		raw["value_schema"] = []interface{}{valueSchema}
+	}
+	return raw
+}
+
+func topicParams(params []KafkaTopicConfig) map[string]string {
+	values := map[string]string{}
+	for _, param := range params {
+		values[param.Name] = param.Value
+	}
+	return values
+}
+
+func TestTopicSchemaValidationParams(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceTopic().Schema, testTopicConfig(map[string]interface{}{"schema": `"string"`}))
+	if _, exists := topicParams(getParams(d))["confluent.value.schema.validation"]; exists {
+		t.Errorf("expected no validation config by default, it is only accepted by Dedicated clusters")
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceTopic().Schema, testTopicConfig(map[string]interface{}{
+		"schema":                `"string"`,
+		"validation":            true,
+		"subject_name_strategy": "RecordName",
+		"record_name":           "Payment",
+	}))
+	params := topicParams(getParams(d))
+	if params["confluent.value.schema.validation"] != "true" || params["confluent.value.subject.name.strategy"] != "io.confluent.kafka.serializers.subject.RecordNameStrategy" {
+		t.Errorf("unexpected validation configs: %v", params)
+	}
+}
+
+func TestTopicSchemaConfigsToReset(t *testing.T) {
+	r := resourceTopic()
+	prior := schema.TestResourceDataRaw(t, r.Schema, testTopicConfig(map[string]interface{}{"schema": `"string"`, "validation": true}))
+	prior.SetId("payments")
+	state := prior.State()
+
+	cases := []struct {
+		valueSchema map[string]interface{}
+		reset       int
+	}{
+		{nil, 2},
+		{map[string]interface{}{"schema": `"string"`, "validation": false}, 2},
+		{map[string]interface{}{"schema": `"string"`, "validation": true}, 0},
+	}
+	for _, c := range cases {
+		diff, err := r.Diff(state, terraform.NewResourceConfigRaw(testTopicConfig(c.valueSchema)), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := schema.InternalMap(r.Schema).Data(state, diff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names := topicSchemaConfigsToReset(d); len(names) != c.reset {
+			t.Errorf("with value_schema %v, expected %d configs to reset, got %v", c.valueSchema, c.reset, names)
+		}
+	}
+}